@@ -0,0 +1,78 @@
+package gotable
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// Reducer combines values of a tree node and all of its descendants into the value of the node, see Column.Aggregate.
+// The value of the node is looked up from its fields as usual when the reducer returns nil.
+type Reducer func(values []any) any
+
+// ReduceSum adds up numeric values, the sum is an int64 when all values are integers and a float64 otherwise
+func ReduceSum(values []any) any {
+	var isum int64
+	var fsum float64
+	isInt := true
+	for _, v := range values {
+		f, ok := toFloat64(v)
+		if !ok {
+			continue
+		}
+		fsum += f
+		switch reflect.ValueOf(v).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			isum += reflect.ValueOf(v).Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			isum += int64(reflect.ValueOf(v).Uint())
+		default:
+			isInt = false
+		}
+	}
+	if isInt {
+		return isum
+	}
+	return fsum
+}
+
+// ReduceMax returns the largest numeric value, nil is returned when there is no numeric value
+func ReduceMax(values []any) any {
+	var out any
+	var fmax float64
+	for _, v := range values {
+		f, ok := toFloat64(v)
+		if ok && (out == nil || f > fmax) {
+			out, fmax = v, f
+		}
+	}
+	return out
+}
+
+// ReduceCount returns the number of nodes which have a value, including the node itself
+func ReduceCount(values []any) any {
+	return len(values)
+}
+
+// Percent is a percentage shown with one decimal, e.g. 12.5%
+type Percent float64
+
+func (a Percent) String() string {
+	return strconv.FormatFloat(float64(a), 'f', 1, 64) + "%"
+}
+
+// percentOfParent returns v as a percentage of the value of the parent, roots are 100% and an empty string is returned
+// when either value is not a number or the value of the parent is 0
+func percentOfParent(v any, parent any, isRoot bool) any {
+	f, ok := toFloat64(v)
+	if !ok {
+		return ""
+	}
+	if isRoot {
+		return Percent(100)
+	}
+	p, ok := toFloat64(parent)
+	if !ok || p == 0 {
+		return ""
+	}
+	return Percent(f / p * 100)
+}
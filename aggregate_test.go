@@ -0,0 +1,105 @@
+package gotable
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Aggregate Test Suites", func() {
+	It("reducer-case1", func() {
+		Expect(ReduceSum([]any{1, int8(2), uint(3), "x"})).Should(Equal(int64(6)))
+		Expect(ReduceSum([]any{1, 2.5})).Should(Equal(3.5))
+		Expect(ReduceSum([]any{})).Should(Equal(int64(0)))
+		Expect(ReduceMax([]any{1, 7.5, int64(3), "x"})).Should(Equal(7.5))
+		Expect(ReduceMax([]any{"x"})).Should(BeNil())
+		Expect(ReduceCount([]any{1, "x"})).Should(Equal(2))
+		Expect(Percent(12.345).String()).Should(Equal("12.3%"))
+	})
+	It("tree-case1", func() {
+		roots, err := BuildTreeFromPaths([]map[string]any{
+			{"path": "src/a.go", "size": 30},
+			{"path": "src/lib/b.go", "size": 10},
+			{"path": "src/lib/c.go", "size": 50},
+			{"path": "README", "size": 10},
+		}, "path", "/", "name")
+		Expect(err).Should(BeNil())
+		tb := NewTable(nil)
+		tb.AppendColumn(
+			test_NewStdColumn("name"),
+			test_NewStdColumn("size").Aggregate(ReduceSum),
+			test_NewStdColumn("largest").FieldPath("size").Aggregate(ReduceMax),
+			test_NewStdColumn("files").FieldPath("size").Aggregate(ReduceCount),
+			test_NewStdColumn("share").PercentOf("size"),
+		)
+		opts := TreeOptions{Collapse: func(n TreeNodeReader) bool { return n.Fields()["name"] == "lib" }}
+		Expect(tb.AppendTreesWithOptions(*DefaultTreePathStyle(), opts, roots...)).Should(BeNil())
+		percent := func(v, p float64) Percent {
+			return Percent(v / p * 100)
+		}
+		rows := [][]any{}
+		for _, row := range tb.All() {
+			rows = append(rows, row[1:])
+		}
+		Expect(rows).Should(Equal([][]any{
+			{"src", int64(90), 50, 3, Percent(100)},
+			{"a.go", int64(30), 30, 1, percent(30, 90)},
+			{"lib", int64(60), 50, 2, percent(60, 90)},
+			{"README", int64(10), 10, 1, Percent(100)},
+		}))
+		Expect(tb.RowStrings(2)[5]).Should(Equal("66.7%"))
+	})
+	It("tree-case2", func() {
+		// leaves of the aggregated column must still have values
+		tb := NewTable(nil)
+		tb.AppendColumn(test_NewStdColumn("size").Aggregate(ReduceSum), test_NewStdColumn("share").PercentOf("size"))
+		root := NewTreeNode(map[string]any{}).AppendChildren(NewTreeNode(map[string]any{"size": 0}), NewTreeNode(map[string]any{"size": "n/a"}))
+		Expect(tb.AppendTrees(*DefaultTreePathStyle(), root)).Should(BeNil())
+		Expect(tb.RowMap(0)["size"]).Should(Equal(int64(0)))
+		Expect(tb.RowMap(1)["share"]).Should(Equal(""))
+		Expect(tb.RowMap(2)["share"]).Should(Equal(""))
+
+		// leaves without the field keep the default value
+		tb = NewTable(nil)
+		tb.AppendColumn(test_NewStdColumn("files").Aggregate(ReduceCount).Default("-"))
+		root = NewTreeNode(map[string]any{}).AppendChildren(NewTreeNode(map[string]any{"files": "a"}), NewTreeNode(map[string]any{}))
+		Expect(tb.AppendTrees(*DefaultTreePathStyle(), root)).Should(BeNil())
+		Expect(tb.Row(0)[1:]).Should(Equal([]any{1}))
+		Expect(tb.Row(1)[1:]).Should(Equal([]any{1}))
+		Expect(tb.Row(2)[1:]).Should(Equal([]any{"-"}))
+	})
+	It("tree-case4", func() {
+		// values of intermediate nodes are aggregated along with their descendants
+		tb := NewTable(nil).AllowMissingFields("")
+		tb.AppendColumn(test_NewStdColumn("size").Aggregate(ReduceSum), test_NewStdColumn("nodes").FieldPath("size").Aggregate(ReduceCount))
+		root := NewTreeNode(map[string]any{"size": 1}).AppendChildren(
+			NewTreeNode(map[string]any{"size": 2}).AppendChildren(NewTreeNode(map[string]any{"size": 4})),
+			NewTreeNode(map[string]any{}),
+		)
+		Expect(tb.AppendTrees(*DefaultTreePathStyle(), root)).Should(BeNil())
+		Expect(tb.Row(0)[1:]).Should(Equal([]any{int64(7), 3}))
+		Expect(tb.Row(1)[1:]).Should(Equal([]any{int64(6), 2}))
+		Expect(tb.Row(2)[1:]).Should(Equal([]any{int64(4), 1}))
+	})
+	It("tree-case3", func() {
+		// percentages and labels follow renamed columns
+		tb := NewTable(nil)
+		tb.AppendColumn(test_NewStdColumn("name").FieldPath("meta.name"), test_NewStdColumn("size").Aggregate(ReduceSum), test_NewStdColumn("share").PercentOf("size"))
+		sty := *DefaultTreePathStyle()
+		sty.Label = "name"
+		root := NewTreeNode(map[string]any{"meta": map[string]any{"name": "root"}}).AppendChildren(
+			NewTreeNode(map[string]any{"meta": map[string]any{"name": "a"}, "size": 1}),
+			NewTreeNode(map[string]any{"meta": map[string]any{"name": "b"}, "size": 3}),
+		)
+		Expect(tb.AppendTrees(sty, root)).Should(BeNil())
+		Expect(tb.rows[1].label).Should(Equal("a"))
+		Expect(tb.RenameColumn("size", "bytes")).Should(BeNil())
+		Expect(tb.RenameColumn("name", "title")).Should(BeNil())
+		Expect(tb.treePathStyle().Label).Should(Equal("title"))
+		Expect(tb.RowMap(2)["share"]).Should(Equal(Percent(75)))
+
+		root = NewTreeNode(map[string]any{"meta": map[string]any{"name": "c"}}).AppendChildren(NewTreeNode(map[string]any{"meta": map[string]any{"name": "d"}, "bytes": 2}))
+		Expect(tb.AppendTrees(tb.treePathStyle(), root)).Should(BeNil())
+		Expect(tb.rows[4].label).Should(Equal("d"))
+		Expect(tb.RowMap(4)["share"]).Should(Equal(Percent(100)))
+	})
+})
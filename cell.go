@@ -14,6 +14,7 @@ type cellRenderer interface {
 }
 
 type Cell struct {
+	data         any
 	rawData      string
 	leftPadding  string
 	rightPadding string
@@ -25,6 +26,11 @@ func (a *Cell) String() string {
 	return a.rawData
 }
 
+// Data returns the value of the cell before it is converted to text
+func (a *Cell) Data() any {
+	return a.data
+}
+
 func (a *Cell) Style(tss ...TextStyle) {
 	a.style = tss
 }
@@ -32,6 +38,7 @@ func (a *Cell) Style(tss ...TextStyle) {
 func (a *Cell) Value(data any) {
 	tmp := strings.Replace(fmt.Sprintf("%v", data), "\t", "    ", -1)
 	tmp = strings.Replace(tmp, "\r\n", "\n", -1)
+	a.data = data
 	a.rawData = tmp
 }
 
@@ -53,15 +60,7 @@ func (a *Cell) sidePaddingWidth() int {
 }
 
 func (a *Cell) formatText(s string, o Output) string {
-	switch o {
-	case Console:
-		return formatConsoleText(s, a.style...)
-	case ReStructuredText:
-		// TODO: not implemented
-		return s
-	default:
-		return s
-	}
+	return formatText(s, o, a.style...)
 }
 
 func (a *Cell) textStylerWidth(o Output) int {
@@ -178,29 +177,128 @@ func (a *DataCell) stats(c *Cell, wlimit int, o Output) (int, int, error) {
 }
 
 type TreePathCell struct {
-	style *TreePathStyle
+	style          *TreePathStyle
+	overFlowAction ColumnOverFlowAction
+	// extension continues the path on following lines of the cell
+	extension string
+	// label is the text of the node shown after the path
+	label string
+	// elided is the marker of hidden children shown after the label
+	elided string
 }
 
 func (a *TreePathCell) render(c *Cell, w int, h int, o Output) ([]string, error) {
+	lines, err := a.splitIntoLines(c, w, o)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) > h {
+		return nil, ErrInsufficientColumnHeight
+	}
+	ext := a.extension
+	if ext == "" {
+		ext = a.style.ReplacePathAsExtention(c.String())
+	}
 	out := make([]string, h)
+	wContent := w - c.sidePaddingWidth() - c.textStylerWidth(o)
 	for i := 0; i < h; i++ {
 		if i == 0 {
 			out[i] = c.String()
 		} else {
-			out[i] = a.style.ReplacePathAsExtention(c.String())
+			out[i] = ext
+		}
+		if i < len(lines) {
+			out[i] += a.labelSeparator() + lines[i]
 		}
+		out[i] += strings.Repeat(" ", max(wContent-treePathWidth(out[i]), 0))
 		out[i] = c.leftPadding + out[i] + c.rightPadding
 		out[i] = c.formatText(out[i], o)
 	}
 	return out, nil
 }
 
+// splitIntoLines splits the label and the marker of hidden children into lines which fit in the space after the path
+func (a *TreePathCell) splitIntoLines(c *Cell, wlimit int, o Output) ([]string, error) {
+	text := strings.TrimSpace(a.label + " " + a.elided)
+	wPath := treePathWidth(c.String())
+	if text == "" {
+		if wlimit != 0 && wlimit-c.sidePaddingWidth()-c.textStylerWidth(o) < wPath {
+			return nil, fmt.Errorf("%w: no sufficient space for tree path", ErrInsufficientColumnWidth)
+		}
+		return nil, nil
+	}
+	wContentLimit := 0
+	if wlimit != 0 {
+		wContentLimit = wlimit - c.sidePaddingWidth() - c.textStylerWidth(o) - wPath - len(a.labelSeparator())
+		if wContentLimit < MinColumnWidth {
+			return nil, fmt.Errorf("%w: no sufficient space after tree path", ErrInsufficientColumnWidth)
+		}
+	}
+	out := []string{}
+	for _, cl := range strings.Split(text, "\n") {
+		wContent := runewidth.StringWidth(cl)
+		switch a.overFlowAction {
+		case Truncate:
+			if wContentLimit != 0 && wContent > wContentLimit {
+				wContentNew := wContentLimit - runewidth.StringWidth(UnfinishedCellTailer)
+				tmp := runewidth.Truncate(cl, wContentNew, "")
+				cl = tmp + strings.Repeat(" ", wContentNew-runewidth.StringWidth(tmp)) + UnfinishedCellTailer
+			}
+			out = append(out, cl)
+		case Wordwrap:
+			out = append(out, splitStringByWidth(cl, wContentLimit)...)
+		case Exception:
+			if wContentLimit != 0 && wContent > wContentLimit {
+				return nil, fmt.Errorf("%w: cell overflow action is set to exception", ErrInsufficientColumnWidth)
+			}
+			out = append(out, cl)
+		}
+	}
+	return out, nil
+}
+
 func (a *TreePathCell) stats(c *Cell, wlimit int, o Output) (int, int, error) {
+	lines, err := a.splitIntoLines(c, wlimit, o)
+	if err != nil {
+		return 0, 0, err
+	}
+	height := max(len(lines), 1)
+	if wlimit != 0 {
+		return wlimit, height, nil
+	}
+	width := treePathWidth(c.String())
+	for _, l := range lines {
+		width = max(width, treePathWidth(c.String())+len(a.labelSeparator())+runewidth.StringWidth(l))
+	}
+	return width + c.sidePaddingWidth() + c.textStylerWidth(o), height, nil
+}
+
+// minWidth returns the width the cell needs at least, the path is never wrapped
+func (a *TreePathCell) minWidth(c *Cell, o Output) int {
+	if strings.TrimSpace(a.label+" "+a.elided) == "" {
+		return c.sidePaddingWidth() + c.textStylerWidth(o) + treePathWidth(c.String())
+	}
+	if a.overFlowAction == Exception {
+		w, _, _ := a.stats(c, 0, o)
+		return w
+	}
+	return c.sidePaddingWidth() + c.textStylerWidth(o) + treePathWidth(c.String()) + len(a.labelSeparator()) + MinColumnWidth
+}
+
+// labelSeparator returns the text between the path and the label, labels follow connectors directly when they are in the path
+func (a *TreePathCell) labelSeparator() string {
+	if a.style.LabelInPath {
+		return ""
+	}
+	return " "
+}
+
+// treePathWidth measures a tree path, box drawing characters are counted as narrow characters
+func treePathWidth(s string) int {
 	// use utf8.RuneCountInString instead of runewidth.StringWidth since some character
 	rw := &runewidth.Condition{
 		EastAsianWidth:     false,
 		StrictEmojiNeutral: true,
 	}
-	w := c.sidePaddingWidth() + c.textStylerWidth(o) + rw.StringWidth(c.String())
-	return w, 1, nil
+	return rw.StringWidth(s)
 }
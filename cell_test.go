@@ -176,7 +176,7 @@ var _ = Describe("Cell Test Suites", func() {
 			out, err := c.render(13, 1, Console)
 			Expect(err).Should(BeNil())
 			expects := []string{
-				"\x1b[44m\x1b[31m\x1b[1m ab cd ef gh \x1b[22m\x1b[0m\x1b[0m",
+				"\x1b[1;31;44m ab cd ef gh \x1b[22;39;49m",
 			}
 			Expect(out).Should(Equal(expects))
 		})
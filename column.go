@@ -6,6 +6,8 @@ type columnCellMaker interface {
 
 type Column struct {
 	name             string
+	title            string
+	subtitle         string
 	hidden           bool
 	widthLimit       int
 	autoWidthControl bool
@@ -14,6 +16,13 @@ type Column struct {
 	padding          rune
 	header           ColumnStyle
 	body             ColumnStyle
+	rules            []*FormatRule
+	defaultValue     any
+	hasDefault       bool
+	fieldPath        []pathSegment
+	fieldPathErr     error
+	reducer          Reducer
+	percentOf        string
 	columnCellMaker
 }
 
@@ -21,6 +30,47 @@ func (a *Column) Name() string {
 	return a.name
 }
 
+// Title sets the text shown in the header, the name of the column is shown when the title is empty.
+// The name is still used as the key of the column.
+func (a *Column) Title(s string) *Column {
+	a.title = s
+	return a
+}
+
+// Subtitle sets an extra header line below the title, e.g. the unit of values
+func (a *Column) Subtitle(s string) *Column {
+	a.subtitle = s
+	return a
+}
+
+// FieldPath binds the column to a nested field of rows built from fields or structs,
+// e.g. "metadata.labels.app" or "spec.containers[0].image". The name of the column is used as the key by default.
+func (a *Column) FieldPath(p string) *Column {
+	a.fieldPath, a.fieldPathErr = parseFieldPath(p)
+	return a
+}
+
+// Aggregate computes the value of the column on rows of tree nodes from their own values and values of all of their
+// descendants, intermediate nodes included. Hidden descendants are included so that trees are walked entirely. Rows of
+// leaves show the reducer applied to their own value, e.g. 1 for ReduceCount.
+func (a *Column) Aggregate(r Reducer) *Column {
+	a.reducer = r
+	return a
+}
+
+// PercentOf makes the column of tree rows show the value of the named column as a percentage of the value of the parent
+func (a *Column) PercentOf(name string) *Column {
+	a.percentOf = name
+	return a
+}
+
+// Default sets the value used when a row built from fields has no field for the column
+func (a *Column) Default(v any) *Column {
+	a.defaultValue = v
+	a.hasDefault = true
+	return a
+}
+
 func (a *Column) Hidden(b bool) *Column {
 	a.hidden = b
 	return a
@@ -49,10 +99,16 @@ func (a *Column) BodyStyle(sty *ColumnStyle) *Column {
 	return a
 }
 
-func (a *Column) newHeader() *Cell {
-	if a.header.overFlowAction == Wordwrap {
-		a.header.escapeLineFeed = false
-	}
+// Rules sets conditional formatting rules of body cells, the first matching rule overrides text styles of the body
+func (a *Column) Rules(rs ...*FormatRule) *Column {
+	a.rules = rs
+	return a
+}
+
+// newHeader creates the header cell, texts of the title and the subtitle are translated by tr if it is not nil
+func (a *Column) newHeader(tr func(string) string) *Cell {
+	// line feeds are kept when the header is wrapped
+	escape := a.header.escapeLineFeed && a.header.overFlowAction != Wordwrap
 	cell := &Cell{
 		leftPadding:  a.leftPadding,
 		rightPadding: a.rightPadding,
@@ -60,14 +116,37 @@ func (a *Column) newHeader() *Cell {
 		cellRenderer: &DataCell{
 			padding:        a.padding,
 			overFlowAction: a.header.overFlowAction,
-			escapeLineFeed: a.header.escapeLineFeed,
+			escapeLineFeed: escape,
 			align:          a.header.align,
 		},
 	}
-	cell.Value(a.name)
+	cell.Value(a.headerText(tr))
 	return cell
 }
 
+// headerText returns the text of the header, the subtitle follows the title in a new line
+func (a *Column) headerText(tr func(string) string) string {
+	text := a.title
+	if text == "" {
+		text = a.name
+	}
+	text = translate(tr, text)
+	if a.subtitle != "" {
+		text += "\n" + translate(tr, a.subtitle)
+	}
+	return text
+}
+
+func translate(tr func(string) string, s string) string {
+	if tr == nil {
+		return s
+	}
+	if out := tr(s); out != "" {
+		return out
+	}
+	return s
+}
+
 func (a *Column) newCell(v any) *Cell {
 	return a.columnCellMaker.newCell(a, v)
 }
@@ -80,8 +159,10 @@ func (a *TreePathColumn) newCell(col *Column, v any) *Cell {
 	cell := &Cell{
 		leftPadding:  col.leftPadding,
 		rightPadding: col.rightPadding,
+		style:        col.body.text,
 		cellRenderer: &TreePathCell{
-			style: &a.sty,
+			style:          &a.sty,
+			overFlowAction: col.body.overFlowAction,
 		},
 	}
 	cell.Value(v)
@@ -95,7 +176,7 @@ func (a *StandardColumn) newCell(col *Column, v any) *Cell {
 	cell := &Cell{
 		leftPadding:  col.leftPadding,
 		rightPadding: col.rightPadding,
-		style:        col.body.text,
+		style:        applyFormatRules(col.rules, v, col.body.text),
 		cellRenderer: &DataCell{
 			padding:        col.padding,
 			overFlowAction: col.body.overFlowAction,
@@ -127,12 +208,15 @@ func NewTreePathColumn(sty TreePathStyle) *Column {
 	header := DefauleHeaderStyle()
 	header.text = sty.header
 	body := DefauleTreeStyle()
+	if sty.hasOverflow {
+		body.OverFlowAction(sty.overflow)
+	}
 	body.text = sty.body
 	col := &Column{
 		name:             sty.Name,
 		hidden:           false,
 		widthLimit:       0,
-		autoWidthControl: false,
+		autoWidthControl: sty.hasOverflow,
 		leftPadding:      " ",
 		rightPadding:     " ",
 		padding:          ' ',
@@ -1,8 +1,13 @@
 package gotable
 
 import (
+	"cmp"
 	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/mattn/go-runewidth"
 )
@@ -65,40 +70,77 @@ func splitStringByWidth(s string, wLimit int) []string {
 	}
 }
 
+func formatText(s string, o Output, tss ...TextStyle) string {
+	switch o {
+	case Console:
+		return formatConsoleText(s, tss...)
+	case ReStructuredText:
+		// TODO: not implemented
+		return s
+	default:
+		return s
+	}
+}
+
+// sgrSequence matches SGR escape sequences of styled texts
+var sgrSequence = regexp.MustCompile("\x1b\\[([0-9;]*)m")
+
+// sgrResets are SGR parameters which turn off attributes or colors
+var sgrResets = []string{"", "0", "22", "23", "24", "25", "27", "29", "39", "49"}
+
+// formatConsoleText wraps str with a single combined SGR sequence, later colors override earlier ones in the same group.
+// Attributes are turned off with their own reset codes instead of \033[0m so that styles of outer text are kept. Texts
+// which are styled already may turn off the style in the middle, e.g. bold and dim share the same reset code, so the
+// style is opened again after every reset of the inner text.
 func formatConsoleText(str string, tss ...TextStyle) string {
 	if len(tss) == 0 {
 		return str
 	}
-	out := str
-	fmtColor := "%s"
-	fmtBgColor := "%s"
+	ons, offs := []string{}, []string{}
+	fg, bg := "", ""
 	for _, ts := range tss {
-		switch ts {
-		case None:
+		if ts == None {
 			return str
-		case Bold:
-			out = fmt.Sprintf("\033[1m%s\033[22m", out)
-		case Red:
-			fmtColor = "\033[31m%s\033[0m"
-		case Green:
-			fmtColor = "\033[32m%s\033[0m"
-		case Yellow:
-			fmtColor = "\033[33m%s\033[0m"
-		case Blue:
-			fmtColor = "\033[34m%s\033[0m"
-		case BgRed:
-			fmtBgColor = "\033[41m%s\033[0m"
-		case BgGreen:
-			fmtBgColor = "\033[42m%s\033[0m"
-		case BgYellow:
-			fmtBgColor = "\033[43m%s\033[0m"
-		case BgBlue:
-			fmtBgColor = "\033[44m%s\033[0m"
+		}
+		on, off, group, ok := ts.sgr()
+		if !ok {
+			continue
+		}
+		switch group {
+		case sgrForeground:
+			fg = on
+		case sgrBackground:
+			bg = on
+		default:
+			if !slices.Contains(ons, on) {
+				ons = append(ons, on)
+			}
+			if !slices.Contains(offs, off) {
+				offs = append(offs, off)
+			}
 		}
 	}
-	out = fmt.Sprintf(fmtColor, out)
-	out = fmt.Sprintf(fmtBgColor, out)
-	return out
+	if fg != "" {
+		ons = append(ons, fg)
+		offs = append(offs, "39")
+	}
+	if bg != "" {
+		ons = append(ons, bg)
+		offs = append(offs, "49")
+	}
+	if len(ons) == 0 {
+		return str
+	}
+	open := "\033[" + strings.Join(ons, ";") + "m"
+	str = sgrSequence.ReplaceAllStringFunc(str, func(seq string) string {
+		for _, p := range strings.Split(sgrSequence.FindStringSubmatch(seq)[1], ";") {
+			if slices.Contains(sgrResets, p) {
+				return seq + open
+			}
+		}
+		return seq
+	})
+	return open + str + "\033[" + strings.Join(offs, ";") + "m"
 }
 
 func formatAlignment(s string, w int, padding rune, align Align) string {
@@ -134,3 +176,51 @@ func formatAlignment(s string, w int, padding rune, align Align) string {
 	}
 	return out
 }
+
+// toFloat64 converts numeric values to float64, false is returned for non-numeric values
+func toFloat64(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// compareValues orders numbers by their values and times chronologically, other values are compared by their texts.
+// Numbers go before times, and times go before other values.
+func compareValues(a, b any) int {
+	rank := func(v any) int {
+		if _, ok := toFloat64(v); ok {
+			return 0
+		}
+		if _, ok := v.(time.Time); ok {
+			return 1
+		}
+		return 2
+	}
+	ra, rb := rank(a), rank(b)
+	if ra != rb {
+		return cmp.Compare(ra, rb)
+	}
+	switch ra {
+	case 0:
+		fa, _ := toFloat64(a)
+		fb, _ := toFloat64(b)
+		return cmp.Compare(fa, fb)
+	case 1:
+		return a.(time.Time).Compare(b.(time.Time))
+	}
+	text := func(v any) string {
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprintf("%v", v)
+	}
+	return strings.Compare(text(a), text(b))
+}
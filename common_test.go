@@ -98,4 +98,36 @@ var _ = Describe("Common Test Suites", func() {
 		out := formatAlignment(strShort1, 4, ' ', AlignJustify)
 		Expect(out).Should(Equal(strShort1))
 	})
+
+	// formatConsoleText
+	It("formatConsoleText-case1", func() {
+		out := formatConsoleText(strShort1)
+		Expect(out).Should(Equal(strShort1))
+		out = formatConsoleText(strShort1, Bold, None)
+		Expect(out).Should(Equal(strShort1))
+	})
+	It("formatConsoleText-case2", func() {
+		out := formatConsoleText(strShort1, Bold, Red, BgBlue)
+		Expect(out).Should(Equal("\x1b[1;31;44mabcd\x1b[22;39;49m"))
+	})
+	It("formatConsoleText-case3", func() {
+		// later colors override earlier ones, bold and dim share the same reset code
+		out := formatConsoleText(strShort1, Red, Italic, Dim, Bold, BrightGreen, Underline, Strikethrough)
+		Expect(out).Should(Equal("\x1b[3;2;1;4;9;92mabcd\x1b[23;22;24;29;39m"))
+	})
+	It("formatConsoleText-case4", func() {
+		out := formatConsoleText(strShort1, Color256(208), BgRGB(0, 128, 255))
+		Expect(out).Should(Equal("\x1b[38;5;208;48;2;0;128;255mabcd\x1b[39;49m"))
+	})
+	It("formatConsoleText-case5", func() {
+		out := formatConsoleText(strShort1, RGB(1, 2, 3), BgColor256(0), Magenta, BgBrightWhite, Reverse, Blink)
+		Expect(out).Should(Equal("\x1b[7;5;35;107mabcd\x1b[27;25;39;49m"))
+	})
+	It("formatConsoleText-case6", func() {
+		// the outer style is opened again after resets of styled texts
+		out := formatConsoleText("a"+formatConsoleText("b", Red)+"c", BgBlue)
+		Expect(out).Should(Equal("\x1b[44ma\x1b[31mb\x1b[39m\x1b[44mc\x1b[49m"))
+		out = formatConsoleText(formatConsoleText("b", Dim)+"c", Bold)
+		Expect(out).Should(Equal("\x1b[1m\x1b[2mb\x1b[22m\x1b[1mc\x1b[22m"))
+	})
 })
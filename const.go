@@ -4,4 +4,5 @@ const (
 	MinColumnWidth           int    = 2
 	AdjustableColumnMinWidth int    = 6
 	UnfinishedCellTailer     string = " ~"
+	DefaultElidedMarker      string = "(+%d more)"
 )
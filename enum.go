@@ -15,6 +15,7 @@ const (
 	ReStructuredText
 	Yaml
 	Json
+	Html
 )
 
 const (
@@ -28,6 +29,36 @@ const (
 	BgGreen
 	BgYellow
 	BgBlue
+	Italic
+	Underline
+	Dim
+	Blink
+	Reverse
+	Strikethrough
+	Black
+	Magenta
+	Cyan
+	White
+	BgBlack
+	BgMagenta
+	BgCyan
+	BgWhite
+	BrightBlack
+	BrightRed
+	BrightGreen
+	BrightYellow
+	BrightBlue
+	BrightMagenta
+	BrightCyan
+	BrightWhite
+	BgBrightBlack
+	BgBrightRed
+	BgBrightGreen
+	BgBrightYellow
+	BgBrightBlue
+	BgBrightMagenta
+	BgBrightCyan
+	BgBrightWhite
 )
 
 const (
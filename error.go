@@ -5,13 +5,25 @@ import "errors"
 var (
 	ErrColumnAlreadyExist       = errors.New("column already exist")
 	ErrColumnNotExist           = errors.New("column does not exist")
+	ErrDuplicateTreeNode        = errors.New("duplicate tree node")
 	ErrEnforcingTableWidth      = errors.New("enforcing table width failed")
 	ErrFieldIsMissing           = errors.New("field is missing")
+	ErrIndexOutOfRange          = errors.New("index out of range")
 	ErrInsufficientColumnHeight = errors.New("insufficient column height")
 	ErrInsufficientColumnWidth  = errors.New("insufficient column width")
 	ErrInvalidCellHeight        = errors.New("invalid cell height")
 	ErrInvalidCellWidth         = errors.New("invalid cell width")
+	ErrInvalidFieldPath         = errors.New("invalid field path")
+	ErrInvalidInput             = errors.New("invalid input")
+	ErrInvalidLayout            = errors.New("invalid layout")
+	ErrInvalidLayoutTemplate    = errors.New("invalid layout template")
+	ErrInvalidTheme             = errors.New("invalid theme")
+	ErrLayoutAlreadyExist       = errors.New("layout already exist")
+	ErrLayoutNotExist           = errors.New("layout does not exist")
 	ErrNoAdjustableColumn       = errors.New("no adjustable column")
+	ErrOrphanTreeNode           = errors.New("parent of tree node does not exist")
 	ErrRenderTableFailed        = errors.New("render table failed")
 	ErrTableNotEmpty            = errors.New("table is not empty")
+	ErrTreeCycle                = errors.New("tree contains a cycle")
+	ErrUnknownField             = errors.New("unknown field")
 )
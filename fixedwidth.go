@@ -0,0 +1,155 @@
+package gotable
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// fixedWidthSpan is the range of runes of a column in every line
+type fixedWidthSpan struct {
+	start int
+	end   int
+	name  string
+}
+
+// NewTableFromFixedWidth builds a table from whitespace aligned output of commands like ps, df or docker ps.
+// The first non-empty line is the header. Boundaries of columns are the positions where every line has a space,
+// values may contain spaces as long as they stay under their header. A header word which has no values and is
+// separated from the previous one by a single space is a part of the previous header, e.g. "Mounted on".
+// Positions are counted in runes, tabs are expanded to 8 columns.
+func NewTableFromFixedWidth(r io.Reader, opts *ImportOptions) (*Table, error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	lines := [][]rune{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimRight(expandTabs(sc.Text()), " \r")
+		if line == "" {
+			continue
+		}
+		lines = append(lines, []rune(line))
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: header is missing", ErrInvalidInput)
+	}
+
+	spans := fixedWidthSpans(lines)
+	names := make([]string, len(spans))
+	for i, sp := range spans {
+		names[i] = sp.name
+	}
+	records := make([][]any, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		rec := make([]any, len(spans))
+		for i, sp := range spans {
+			end := sp.end
+			if i == len(spans)-1 {
+				// the last column takes the rest of the line
+				end = len(line)
+			}
+			rec[i] = runeSlice(line, sp.start, end)
+		}
+		records = append(records, rec)
+	}
+
+	tb, err := newImportedTable(names, records, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		if err := tb.AppendRow(rec...); err != nil {
+			return nil, err
+		}
+	}
+	return tb, nil
+}
+
+// fixedWidthSpans finds runs of positions that are not a space in all lines, then merges runs without a header and
+// runs of header words that are a part of the previous header
+func fixedWidthSpans(lines [][]rune) []fixedWidthSpan {
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(line))
+	}
+	used := make([]bool, width)
+	for _, line := range lines {
+		for i, r := range line {
+			if r != ' ' {
+				used[i] = true
+			}
+		}
+	}
+
+	out := []fixedWidthSpan{}
+	header := lines[0]
+	for start := 0; start < width; {
+		if !used[start] {
+			start++
+			continue
+		}
+		end := start
+		for end < width && used[end] {
+			end++
+		}
+		name := runeSlice(header, start, end)
+		if len(out) > 0 {
+			last := &out[len(out)-1]
+			if last.name == "" || name == "" || start-last.end == 1 && isEmptyFixedWidthSpan(lines[1:], start, end) {
+				last.end = end
+				last.name = runeSlice(header, last.start, end)
+				start = end
+				continue
+			}
+		}
+		out = append(out, fixedWidthSpan{start: start, end: end, name: name})
+		start = end
+	}
+	return out
+}
+
+func isEmptyFixedWidthSpan(lines [][]rune, start, end int) bool {
+	for _, line := range lines {
+		if runeSlice(line, start, end) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// runeSlice returns runes of the line in [start, end) with spaces trimmed, positions beyond the line are ignored
+func runeSlice(line []rune, start, end int) string {
+	end = min(end, len(line))
+	if start >= end {
+		return ""
+	}
+	return strings.TrimSpace(string(line[start:end]))
+}
+
+func expandTabs(s string) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	sb := strings.Builder{}
+	n := 0
+	for _, r := range s {
+		if r == '\t' {
+			for {
+				sb.WriteRune(' ')
+				n++
+				if n%8 == 0 {
+					break
+				}
+			}
+			continue
+		}
+		sb.WriteRune(r)
+		n++
+	}
+	return sb.String()
+}
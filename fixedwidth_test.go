@@ -0,0 +1,66 @@
+package gotable
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func test_ColumnNames(tb *Table) []string {
+	out := []string{}
+	for _, col := range tb.Columns() {
+		out = append(out, col.Name())
+	}
+	return out
+}
+
+var _ = Describe("Fixed Width Test Suites", func() {
+	It("df-case1", func() {
+		doc := `
+Filesystem     1K-blocks     Used Available Use% Mounted on
+/dev/sda1       41152736 12345678  26704812  32% /
+tmpfs            1018032        0   1018032   0% /dev/shm
+/dev/sdb1      103081248  1234567  96587416   2% /boot
+`
+		tb, err := NewTableFromFixedWidth(strings.NewReader(doc), &ImportOptions{InferTypes: true})
+		Expect(err).Should(BeNil())
+		Expect(test_ColumnNames(tb)).Should(Equal([]string{"Filesystem", "1K-blocks", "Used", "Available", "Use%", "Mounted on"}))
+		Expect(tb.Row(1)).Should(Equal([]any{"tmpfs", int64(1018032), int64(0), int64(1018032), "0%", "/dev/shm"}))
+		Expect(tb.Columns()[1].body.align).Should(Equal(AlignRight))
+	})
+	It("ps-case1", func() {
+		doc := "  PID TTY          TIME CMD\n" +
+			"    1 ?        00:00:02 /sbin/init splash\n" +
+			"  812 pts/0    00:00:00 bash -c echo  hi\n"
+		tb, err := NewTableFromFixedWidth(strings.NewReader(doc), nil)
+		Expect(err).Should(BeNil())
+		Expect(test_ColumnNames(tb)).Should(Equal([]string{"PID", "TTY", "TIME", "CMD"}))
+		Expect(tb.Row(0)).Should(Equal([]any{"1", "?", "00:00:02", "/sbin/init splash"}))
+		Expect(tb.Row(1)).Should(Equal([]any{"812", "pts/0", "00:00:00", "bash -c echo  hi"}))
+	})
+	It("docker-case1", func() {
+		doc := "CONTAINER ID   IMAGE          COMMAND                  STATUS\n" +
+			"4c01db0b339c   ubuntu:22.04   \"bash\"                   Up 2 hours\n" +
+			"d7886598dbe2   nginx          \"/docker-entrypoint.…\"   Exited (0) 3 days ago\n"
+		tb, err := NewTableFromFixedWidth(strings.NewReader(doc), nil)
+		Expect(err).Should(BeNil())
+		Expect(test_ColumnNames(tb)).Should(Equal([]string{"CONTAINER ID", "IMAGE", "COMMAND", "STATUS"}))
+		Expect(tb.Row(1)).Should(Equal([]any{"d7886598dbe2", "nginx", "\"/docker-entrypoint.…\"", "Exited (0) 3 days ago"}))
+		Expect(tb.Row(0)[3]).Should(Equal("Up 2 hours"))
+	})
+	It("kubectl-case1", func() {
+		doc := "NAME\tREADY\tSTATUS\n" +
+			"web-1\t1/1\tRunning\n" +
+			"db-0\t0/1\tCrashLoopBackOff\n"
+		tb, err := NewTableFromFixedWidth(strings.NewReader(doc), nil)
+		Expect(err).Should(BeNil())
+		Expect(test_ColumnNames(tb)).Should(Equal([]string{"NAME", "READY", "STATUS"}))
+		Expect(tb.Row(1)).Should(Equal([]any{"db-0", "0/1", "CrashLoopBackOff"}))
+	})
+	It("error-case1", func() {
+		_, err := NewTableFromFixedWidth(strings.NewReader("\n  \n"), nil)
+		Expect(errors.Is(err, ErrInvalidInput)).Should(BeTrue())
+	})
+})
@@ -0,0 +1,248 @@
+package gotable
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ImportOptions controls how a table is built from a data file, each importer honors only the options that apply to
+// its source and ignores the others
+type ImportOptions struct {
+	// Layout of the table, the default layout is used when it is nil. It is honored by all importers, ParseTable also
+	// tries it first to recognize the borders of the input.
+	Layout *TableLayout
+	// InferTypes converts texts of a column to int64, float64, bool or time.Time (RFC3339) when all of them can be
+	// converted, empty texts are kept as is. Numeric columns are right aligned. It is honored by NewTableFromCSV,
+	// NewTableFromTSV, NewTableFromJSON, NewTableFromFixedWidth and ParseTable.
+	InferTypes bool
+	// NullMarker replaces NULL values of SQL results, an empty string is used when it is nil. It is honored by
+	// NewTableFromSQL only.
+	NullMarker any
+	// Limit stops reading SQL results after the number of rows, 0 means no limit. It is honored by NewTableFromSQL only.
+	Limit int
+	// RowHeights are line counts of body rows of a rendered table, see Table.Stats. They are used by ParseTable to merge
+	// wrapped cells when the table has no row separators and must cover all lines of the body, every line is a row
+	// when they are not set. It is honored by ParseTable only.
+	RowHeights []int
+}
+
+// NewTableFromCSV builds a table from comma separated values, the first record is used as names of columns
+func NewTableFromCSV(r io.Reader, opts *ImportOptions) (*Table, error) {
+	return newTableFromDelimited(r, ',', opts)
+}
+
+// NewTableFromTSV builds a table from tab separated values, the first record is used as names of columns
+func NewTableFromTSV(r io.Reader, opts *ImportOptions) (*Table, error) {
+	return newTableFromDelimited(r, '\t', opts)
+}
+
+// NewTableFromJSON builds a table from a JSON array of objects. Columns are the union of keys in first-seen order,
+// missing fields and nulls are rendered as empty cells.
+func NewTableFromJSON(r io.Reader, opts *ImportOptions) (*Table, error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := expectDelim(dec, '['); err != nil {
+		return nil, err
+	}
+	names := []string{}
+	index := map[string]int{}
+	records := [][]any{}
+	for dec.More() {
+		if err := expectDelim(dec, '{'); err != nil {
+			return nil, err
+		}
+		rec := make([]any, len(names))
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+			}
+			key := tok.(string)
+			var v any
+			if err := dec.Decode(&v); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+			}
+			i, ok := index[key]
+			if !ok {
+				i = len(names)
+				index[key] = i
+				names = append(names, key)
+			}
+			for len(rec) <= i {
+				rec = append(rec, nil)
+			}
+			rec[i] = v
+		}
+		if err := expectDelim(dec, '}'); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	if err := expectDelim(dec, ']'); err != nil {
+		return nil, err
+	}
+	for i := range records {
+		for len(records[i]) < len(names) {
+			records[i] = append(records[i], nil)
+		}
+	}
+
+	tb, err := newImportedTable(names, records, opts)
+	if err != nil {
+		return nil, err
+	}
+	// absent keys and null values are filled during the import, so that the table does not allow missing fields
+	for _, rec := range records {
+		m := make(map[string]any, len(names))
+		for i, name := range names {
+			m[name] = rec[i]
+			if rec[i] == nil {
+				m[name] = ""
+			}
+		}
+		if err := tb.AppendRowM(m); err != nil {
+			return nil, err
+		}
+	}
+	return tb, nil
+}
+
+func newTableFromDelimited(r io.Reader, comma rune, opts *ImportOptions) (*Table, error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	if comma == '\t' {
+		cr.LazyQuotes = true
+	}
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: header is missing", ErrInvalidInput)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+	}
+	records := [][]any{}
+	for {
+		fields, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+		}
+		rec := make([]any, len(fields))
+		for i, f := range fields {
+			rec[i] = f
+		}
+		records = append(records, rec)
+	}
+
+	tb, err := newImportedTable(header, records, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		if err := tb.AppendRow(rec...); err != nil {
+			return nil, err
+		}
+	}
+	return tb, nil
+}
+
+// newImportedTable creates an empty table with a column for each name, values of records are converted in place
+// when types are inferred
+func newImportedTable(names []string, records [][]any, opts *ImportOptions) (*Table, error) {
+	tb := NewTable(opts.Layout)
+	for i, name := range names {
+		col := NewStandardColumn(name)
+		if opts.InferTypes && inferColumn(records, i) {
+			col.body.align = AlignRight
+		}
+		if _, err := tb.AppendColumn(col); err != nil {
+			return nil, err
+		}
+	}
+	return tb, nil
+}
+
+// inferColumn converts texts of the i-th field of records to the first type that all of them can be converted to,
+// it returns true when the column is numeric
+func inferColumn(records [][]any, i int) bool {
+	texts := []string{}
+	for _, rec := range records {
+		if s, ok := importedText(rec[i]); ok && s != "" {
+			texts = append(texts, s)
+		}
+	}
+	if len(texts) == 0 {
+		return false
+	}
+	parsers := []struct {
+		numeric bool
+		parse   func(string) (any, bool)
+	}{
+		{true, func(s string) (any, bool) { n, err := strconv.ParseInt(s, 10, 64); return n, err == nil }},
+		{true, func(s string) (any, bool) { f, err := strconv.ParseFloat(s, 64); return f, err == nil }},
+		{false, func(s string) (any, bool) {
+			switch strings.ToLower(s) {
+			case "true":
+				return true, true
+			case "false":
+				return false, true
+			}
+			return nil, false
+		}},
+		{false, func(s string) (any, bool) { t, err := time.Parse(time.RFC3339, s); return t, err == nil }},
+	}
+	for _, p := range parsers {
+		ok := true
+		for _, s := range texts {
+			if _, ok = p.parse(s); !ok {
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		for _, rec := range records {
+			if s, ok := importedText(rec[i]); ok && s != "" {
+				rec[i], _ = p.parse(s)
+			}
+		}
+		return p.numeric
+	}
+	return false
+}
+
+// importedText returns the text of a CSV field or a JSON string or number
+func importedText(v any) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return string(v), true
+	}
+	return "", false
+}
+
+func expectDelim(dec *json.Decoder, d json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidInput, err)
+	}
+	if tok != d {
+		return fmt.Errorf("%w: expects %q, got %v", ErrInvalidInput, d, tok)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package gotable
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Import Test Suites", func() {
+	It("csv-case1", func() {
+		tb, err := NewTableFromCSV(strings.NewReader("Name,Size\n\"a, b\",10\nc,2\n"), &ImportOptions{Layout: LightTableLayout()})
+		Expect(err).Should(BeNil())
+		Expect(tb.Row(0)).Should(Equal([]any{"a, b", "10"}))
+		out, err := tb.Render(Console)
+		Expect(err).Should(BeNil())
+		expects := []string{
+			"┌──────┬──────┐",
+			"│\x1b[1m Name \x1b[22m│\x1b[1m Size \x1b[22m│",
+			"├──────┼──────┤",
+			"│ a, b │ 10   │",
+			"│ c    │ 2    │",
+			"└──────┴──────┘",
+			"",
+		}
+		Expect(strings.Split(out, "\n")).Should(Equal(expects))
+	})
+	It("csv-case2", func() {
+		tb, err := NewTableFromCSV(strings.NewReader("Name,Size,Ratio,OK,Time\na,10,1,true,2024-01-02T03:04:05Z\nb,,0.5,False,\n"), &ImportOptions{InferTypes: true})
+		Expect(err).Should(BeNil())
+		tm := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		Expect(tb.Row(0)).Should(Equal([]any{"a", int64(10), 1.0, true, tm}))
+		Expect(tb.Row(1)).Should(Equal([]any{"b", "", 0.5, false, ""}))
+		for _, col := range tb.Columns() {
+			if col.Name() == "Size" || col.Name() == "Ratio" {
+				Expect(col.body.align).Should(Equal(AlignRight))
+			} else {
+				Expect(col.body.align).Should(Equal(AlignLeft))
+			}
+		}
+	})
+	It("csv-case3", func() {
+		_, err := NewTableFromCSV(strings.NewReader(""), nil)
+		Expect(errors.Is(err, ErrInvalidInput)).Should(BeTrue())
+		_, err = NewTableFromCSV(strings.NewReader("a,b\n1,2,3\n"), nil)
+		Expect(errors.Is(err, ErrInvalidInput)).Should(BeTrue())
+		_, err = NewTableFromCSV(strings.NewReader("a,a\n1,2\n"), nil)
+		Expect(errors.Is(err, ErrColumnAlreadyExist)).Should(BeTrue())
+	})
+	It("tsv-case1", func() {
+		tb, err := NewTableFromTSV(strings.NewReader("Name\tNote\na\tsay \"hi\"\n"), nil)
+		Expect(err).Should(BeNil())
+		Expect(tb.RowMap(0)).Should(Equal(map[string]any{"Name": "a", "Note": `say "hi"`}))
+	})
+	It("json-case1", func() {
+		doc := `[{"name": "a", "size": 10}, {"size": 2.5, "name": "b", "tags": ["x"]}, {"name": "c", "size": null}]`
+		tb, err := NewTableFromJSON(strings.NewReader(doc), nil)
+		Expect(err).Should(BeNil())
+		names := []string{}
+		for _, col := range tb.Columns() {
+			names = append(names, col.Name())
+		}
+		Expect(names).Should(Equal([]string{"name", "size", "tags"}))
+		Expect(tb.Row(0)).Should(Equal([]any{"a", json.Number("10"), ""}))
+		Expect(tb.Row(1)).Should(Equal([]any{"b", json.Number("2.5"), []any{"x"}}))
+		Expect(tb.Row(2)).Should(Equal([]any{"c", "", ""}))
+		// the table does not allow missing fields after the import
+		err = tb.AppendRowS(map[string]any{"name": "d"})
+		Expect(errors.Is(err, ErrFieldIsMissing)).Should(BeTrue())
+	})
+	It("json-case2", func() {
+		doc := `[{"id": 1, "at": "2024-01-02T03:04:05Z", "ok": true}, {"id": 2, "at": "", "ok": false}]`
+		tb, err := NewTableFromJSON(strings.NewReader(doc), &ImportOptions{InferTypes: true})
+		Expect(err).Should(BeNil())
+		Expect(tb.Row(0)).Should(Equal([]any{int64(1), time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), true}))
+		Expect(tb.Row(1)).Should(Equal([]any{int64(2), "", false}))
+		Expect(tb.Columns()[0].body.align).Should(Equal(AlignRight))
+	})
+	It("json-case3", func() {
+		for _, doc := range []string{``, `{"a": 1}`, `[1, 2]`, `[{"a": 1}`, `[{"a": }]`} {
+			_, err := NewTableFromJSON(strings.NewReader(doc), nil)
+			Expect(errors.Is(err, ErrInvalidInput)).Should(BeTrue(), doc)
+		}
+	})
+})
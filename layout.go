@@ -0,0 +1,311 @@
+package gotable
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+)
+
+var (
+	layoutRegistryMu sync.RWMutex
+	layoutRegistry   = map[string]func() *TableLayout{
+		"default":      DefaultTableLayout,
+		"light":        LightTableLayout,
+		"double":       DoubleTableLayout,
+		"rounded":      RoundedTableLayout,
+		"heavy":        HeavyTableLayout,
+		"heavy-header": HeavyHeaderTableLayout,
+		"dotted":       DottedTableLayout,
+		"compact":      CompactTableLayout,
+		"borderless":   BorderlessTableLayout,
+		"markdown":     MarkdownTableLayout,
+	}
+)
+
+// RegisterTableLayout registers a layout constructor so that the layout can be looked up by name, names are case-insensitive
+func RegisterTableLayout(name string, f func() *TableLayout) error {
+	key := strings.ToLower(name)
+	layoutRegistryMu.Lock()
+	defer layoutRegistryMu.Unlock()
+	if _, ok := layoutRegistry[key]; ok {
+		return fmt.Errorf("%w: %s", ErrLayoutAlreadyExist, name)
+	}
+	layoutRegistry[key] = f
+	return nil
+}
+
+// GetTableLayout returns a new layout created by the constructor registered with the name
+func GetTableLayout(name string) (*TableLayout, error) {
+	layoutRegistryMu.RLock()
+	f, ok := layoutRegistry[strings.ToLower(name)]
+	layoutRegistryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrLayoutNotExist, name)
+	}
+	return f(), nil
+}
+
+// TableLayoutNames returns sorted names of all registered layouts
+func TableLayoutNames() []string {
+	layoutRegistryMu.RLock()
+	defer layoutRegistryMu.RUnlock()
+	out := make([]string, 0, len(layoutRegistry))
+	for name := range layoutRegistry {
+		out = append(out, name)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// Validate checks that every rune drawn by the layout occupies exactly one cell on the console
+func (a *TableLayout) Validate() error {
+	type item struct {
+		name string
+		r    rune
+		used bool
+	}
+	side, sep := a.ShowSideBorder, a.ShowColumnSeparator
+	rowSepLeft, rowSepRight := a.rowSeparatorSides()
+	items := []item{
+		{"HeaderTopLeft", a.HeaderTopLeft, a.ShowHeaderTopBorder && side},
+		{"HeaderTopRight", a.HeaderTopRight, a.ShowHeaderTopBorder && side},
+		{"HeaderTopSeparator", a.HeaderTopSeparator, a.ShowHeaderTopBorder && sep},
+		{"HeaderTopHorizontal", a.HeaderTopHorizontal, a.ShowHeaderTopBorder},
+		{"HeaderLeft", a.HeaderLeft, a.ShowHeader && side},
+		{"HeaderRight", a.HeaderRight, a.ShowHeader && side},
+		{"HeaderSeparator", a.HeaderSeparator, a.ShowHeader && sep},
+		{"HeaderBottomLeft", a.HeaderBottomLeft, a.ShowHeaderBottemBorder && side},
+		{"HeaderBottomRight", a.HeaderBottomRight, a.ShowHeaderBottemBorder && side},
+		{"HeaderBottomSeparator", a.HeaderBottomSeparator, a.ShowHeaderBottemBorder && sep},
+		{"HeaderBottomHorizontal", a.HeaderBottomHorizontal, a.ShowHeaderBottemBorder},
+		{"BodyTopLeft", a.BodyTopLeft, a.ShowBodyTopBorder && side},
+		{"BodyTopRight", a.BodyTopRight, a.ShowBodyTopBorder && side},
+		{"BodyTopSeparator", a.BodyTopSeparator, a.ShowBodyTopBorder && sep},
+		{"BodyTopHorizontal", a.BodyTopHorizontal, a.ShowBodyTopBorder},
+		{"BodyBottomLeft", a.BodyBottomLeft, a.ShowBodyBottomBorder && side},
+		{"BodyBottomRight", a.BodyBottomRight, a.ShowBodyBottomBorder && side},
+		{"BodyBottomSeparator", a.BodyBottomSeparator, a.ShowBodyBottomBorder && sep},
+		{"BodyBottomHorizontal", a.BodyBottomHorizontal, a.ShowBodyBottomBorder},
+		{"RowLeft", a.RowLeft, side},
+		{"RowRight", a.RowRight, side},
+		{"RowSeparator", a.RowSeparator, a.ShowRowSeparator && sep},
+		{"RowHorizontal", a.RowHorizontal, a.ShowRowSeparator},
+		{"RowSeparatorLeft", rowSepLeft, a.ShowRowSeparator && side},
+		{"RowSeparatorRight", rowSepRight, a.ShowRowSeparator && side},
+		{"ColumnSeparator", a.ColumnSeparator, sep},
+	}
+	rw := &runewidth.Condition{
+		EastAsianWidth: false,
+	}
+	for _, it := range items {
+		if !it.used {
+			continue
+		}
+		if rw.RuneWidth(it.r) != 1 {
+			return fmt.Errorf("%w: %s %q is not single-cell width", ErrInvalidLayout, it.name, it.r)
+		}
+	}
+	return nil
+}
+
+// NewTableLayoutFromTemplate derives a layout from a drawing of a table with a header row and a body row of 2 cells,
+// e.g.
+//
+//	┌───┬───┐
+//	│ H │ H │
+//	├───┼───┤
+//	│ B │ B │
+//	├───┼───┤
+//	└───┴───┘
+//
+// Rows must contain letters or digits, every other line is a horizontal border. Borders that are not drawn are hidden.
+// Two lines between the header and the body are taken as the header bottom and the body top border, and two lines
+// below the body are taken as the row separator and the bottom border. A second body row may be drawn instead, the row
+// separator is then the line between the body rows.
+func NewTableLayoutFromTemplate(tpl string) (*TableLayout, error) {
+	lines := templateLines(tpl)
+	content := []int{}
+	for i, l := range lines {
+		if slices.ContainsFunc(l, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+			content = append(content, i)
+		}
+	}
+	if len(content) != 2 && len(content) != 3 {
+		return nil, fmt.Errorf("%w: expect a header row and 1 or 2 body rows but %d rows are found", ErrInvalidLayoutTemplate, len(content))
+	}
+	rows := make([]templateRow, len(content))
+	for i, idx := range content {
+		row, err := parseTemplateRow(lines[idx])
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 && (row.side != rows[0].side || row.sep != rows[0].sep || row.xSep != rows[0].xSep || row.xRight != rows[0].xRight) {
+			return nil, fmt.Errorf("%w: rows have different borders", ErrInvalidLayoutTemplate)
+		}
+		if i > 1 && (row.left != rows[1].left || row.right != rows[1].right || row.separator != rows[1].separator) {
+			return nil, fmt.Errorf("%w: body rows have different borders", ErrInvalidLayoutTemplate)
+		}
+		rows[i] = row
+	}
+	header, body := rows[0], rows[1]
+	last := content[len(content)-1]
+	above, between, below := lines[:content[0]], lines[content[0]+1:content[1]], lines[last+1:]
+	rowSeps := [][]rune{}
+	if len(content) == 3 {
+		rowSeps = lines[content[1]+1 : content[2]]
+	} else if len(below) == 2 {
+		rowSeps, below = below[:1], below[1:]
+	}
+	if len(above) > 1 || len(between) > 2 || len(rowSeps) > 1 || len(below) > 1 {
+		return nil, fmt.Errorf("%w: too many border lines", ErrInvalidLayoutTemplate)
+	}
+
+	var err error
+	l := &TableLayout{
+		HeaderLeft:          header.left,
+		HeaderRight:         header.right,
+		HeaderSeparator:     header.separator,
+		RowLeft:             body.left,
+		RowRight:            body.right,
+		ColumnSeparator:     body.separator,
+		ColumnPaddingLeft:   " ",
+		ColumnPaddingRight:  " ",
+		CellPadding:         " ",
+		ShowHeader:          true,
+		ShowSideBorder:      header.side,
+		ShowColumnSeparator: header.sep,
+	}
+	border := func(line []rune, left, right, separator, horizontal *rune) error {
+		if len(line) <= header.xRight || (header.sep && len(line) <= header.xSep) {
+			return fmt.Errorf("%w: border %q is shorter than the header row", ErrInvalidLayoutTemplate, string(line))
+		}
+		*horizontal = line[0]
+		if header.side {
+			*left, *right, *horizontal = line[0], line[header.xRight], line[1]
+		}
+		if header.sep {
+			*separator = line[header.xSep]
+		}
+		return nil
+	}
+	if len(above) == 1 {
+		l.ShowHeaderTopBorder = true
+		err = border(above[0], &l.HeaderTopLeft, &l.HeaderTopRight, &l.HeaderTopSeparator, &l.HeaderTopHorizontal)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(between) >= 1 {
+		l.ShowHeaderBottemBorder = true
+		err = border(between[0], &l.HeaderBottomLeft, &l.HeaderBottomRight, &l.HeaderBottomSeparator, &l.HeaderBottomHorizontal)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(between) == 2 {
+		l.ShowBodyTopBorder = true
+		err = border(between[1], &l.BodyTopLeft, &l.BodyTopRight, &l.BodyTopSeparator, &l.BodyTopHorizontal)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(rowSeps) == 1 {
+		l.ShowRowSeparator = true
+		err = border(rowSeps[0], &l.RowSeparatorLeft, &l.RowSeparatorRight, &l.RowSeparator, &l.RowHorizontal)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(below) == 1 {
+		l.ShowBodyBottomBorder = true
+		err = border(below[0], &l.BodyBottomLeft, &l.BodyBottomRight, &l.BodyBottomSeparator, &l.BodyBottomHorizontal)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// top borders of the header and the body are interchangeable when the header is hidden
+	if !l.ShowBodyTopBorder {
+		l.BodyTopLeft, l.BodyTopRight, l.BodyTopSeparator, l.BodyTopHorizontal = l.HeaderTopLeft, l.HeaderTopRight, l.HeaderTopSeparator, l.HeaderTopHorizontal
+	} else if !l.ShowHeaderTopBorder {
+		l.HeaderTopLeft, l.HeaderTopRight, l.HeaderTopSeparator, l.HeaderTopHorizontal = l.BodyTopLeft, l.BodyTopRight, l.BodyTopSeparator, l.BodyTopHorizontal
+	}
+
+	// row separators look like the header bottom border unless they are drawn
+	if !l.ShowRowSeparator {
+		l.RowSeparator, l.RowHorizontal = l.HeaderBottomSeparator, l.HeaderBottomHorizontal
+	}
+
+	err = l.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+type templateRow struct {
+	side      bool
+	sep       bool
+	left      rune
+	right     rune
+	separator rune
+	xSep      int
+	xRight    int
+}
+
+// templateLines splits the template into non-blank lines and removes the common indentation
+func templateLines(tpl string) [][]rune {
+	out := [][]rune{}
+	for _, l := range strings.Split(strings.ReplaceAll(tpl, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(l) != "" {
+			out = append(out, []rune(strings.TrimRightFunc(l, unicode.IsSpace)))
+		}
+	}
+	indent := -1
+	for _, l := range out {
+		n := slices.IndexFunc(l, func(r rune) bool { return !unicode.IsSpace(r) })
+		if indent == -1 || n < indent {
+			indent = n
+		}
+	}
+	for i := range out {
+		out[i] = out[i][indent:]
+	}
+	return out
+}
+
+// parseTemplateRow finds the borders of a row with two cells
+func parseTemplateRow(line []rune) (templateRow, error) {
+	out := templateRow{}
+	borders := []int{}
+	for i, r := range line {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r) {
+			borders = append(borders, i)
+		}
+	}
+	if len(borders) > 0 && borders[0] == 0 {
+		if len(borders) < 2 || borders[len(borders)-1] != len(line)-1 {
+			return out, fmt.Errorf("%w: row %q has no right border", ErrInvalidLayoutTemplate, string(line))
+		}
+		out.side = true
+		out.left, out.right = line[0], line[len(line)-1]
+		out.xRight = len(line) - 1
+		borders = borders[1 : len(borders)-1]
+	}
+	switch len(borders) {
+	case 0:
+		if len(strings.Fields(string(line))) != 2 {
+			return out, fmt.Errorf("%w: row %q does not have 2 cells", ErrInvalidLayoutTemplate, string(line))
+		}
+	case 1:
+		out.sep = true
+		out.separator = line[borders[0]]
+		out.xSep = borders[0]
+	default:
+		return out, fmt.Errorf("%w: row %q does not have 2 cells", ErrInvalidLayoutTemplate, string(line))
+	}
+	return out, nil
+}
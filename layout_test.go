@@ -0,0 +1,247 @@
+package gotable
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Layout Test Suites", func() {
+	Context("registry", func() {
+		It("t1", func() {
+			names := TableLayoutNames()
+			Expect(names).Should(ContainElements("default", "light", "double", "rounded", "heavy", "heavy-header", "dotted", "compact", "borderless", "markdown"))
+			for _, name := range names {
+				l, err := GetTableLayout(name)
+				Expect(err).Should(BeNil())
+				Expect(l).ShouldNot(BeNil())
+			}
+		})
+		It("t2", func() {
+			l, err := GetTableLayout("Rounded")
+			Expect(err).Should(BeNil())
+			Expect(l.HeaderTopLeft).Should(Equal('╭'))
+			// every lookup returns a new copy of the layout
+			l.HeaderTopLeft = '+'
+			l, _ = GetTableLayout("rounded")
+			Expect(l.HeaderTopLeft).Should(Equal('╭'))
+		})
+		It("t3", func() {
+			_, err := GetTableLayout("not-exist")
+			Expect(errors.Is(err, ErrLayoutNotExist)).Should(BeTrue())
+			err = RegisterTableLayout("Light", LightTableLayout)
+			Expect(errors.Is(err, ErrLayoutAlreadyExist)).Should(BeTrue())
+			err = RegisterTableLayout("test-registry", func() *TableLayout {
+				return DefaultTableLayout().HideOutterBorder()
+			})
+			Expect(err).Should(BeNil())
+			l, err := GetTableLayout("test-registry")
+			Expect(err).Should(BeNil())
+			Expect(l.ShowSideBorder).Should(BeFalse())
+		})
+	})
+
+	Context("presets", func() {
+		It("t1", func() {
+			tb := NewTable(RoundedTableLayout())
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data"))
+			tb.AppendRow(1, strShort1)
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				"╭────┬──────╮",
+				"│ ID │ Data │",
+				"├────┼──────┤",
+				"│ 1  │ abcd │",
+				"╰────┴──────╯",
+				"",
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+		})
+		It("t2", func() {
+			tb := NewTable(BorderlessTableLayout())
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data"))
+			tb.AppendRow(1, strShort1)
+			tb.AppendRow(2, strShort2)
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				" ID     Data     ",
+				" 1   abcd        ",
+				" 2   ab cd ef gh ",
+				"",
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+		})
+		It("t3", func() {
+			tb := NewTable(MarkdownTableLayout())
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data"))
+			tb.AppendRow(1, strShort1)
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				"| ID | Data |",
+				"|----|------|",
+				"| 1  | abcd |",
+				"",
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+		})
+		It("t4", func() {
+			tb := NewTable(HeavyHeaderTableLayout())
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data"))
+			tb.AppendRow(1, strShort1)
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				"┏━━━━┳━━━━━━┓",
+				"┃ ID ┃ Data ┃",
+				"┡━━━━╇━━━━━━┩",
+				"│ 1  │ abcd │",
+				"└────┴──────┘",
+				"",
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+		})
+	})
+
+	Context("template", func() {
+		It("t1", func() {
+			l, err := NewTableLayoutFromTemplate(`
+				┌───┬───┐
+				│ H │ H │
+				├───┼───┤
+				│ B │ B │
+				│ B │ B │
+				└───┴───┘`)
+			Expect(err).Should(BeNil())
+			Expect(l).Should(Equal(LightTableLayout()))
+		})
+		It("t2", func() {
+			l, err := NewTableLayoutFromTemplate(`
+				┏━━━┳━━━┓
+				┃ H ┃ H ┃
+				┡━━━╇━━━┩
+				│ B │ B │
+				├───┼───┤
+				│ B │ B │
+				└───┴───┘`)
+			Expect(err).Should(BeNil())
+			expects := HeavyHeaderTableLayout()
+			expects.ShowRowSeparator = true
+			expects.RowSeparator = '┼'
+			expects.RowSeparatorLeft, expects.RowSeparatorRight = '├', '┤'
+			// the body top border is copied from the header top border when it is not drawn
+			expects.BodyTopLeft, expects.BodyTopRight, expects.BodyTopSeparator, expects.BodyTopHorizontal = '┏', '┓', '┳', '━'
+			Expect(l).Should(Equal(expects))
+		})
+		It("t3", func() {
+			l, err := NewTableLayoutFromTemplate("| a | b |\n|---|---|\n| 1 | 2 |\n| 3 | 4 |\n")
+			Expect(err).Should(BeNil())
+			Expect(l.ShowHeaderTopBorder).Should(BeFalse())
+			Expect(l.ShowBodyBottomBorder).Should(BeFalse())
+			Expect(l.HeaderBottomSeparator).Should(Equal('|'))
+			Expect(l.HeaderBottomHorizontal).Should(Equal('-'))
+			Expect(l.ColumnSeparator).Should(Equal('|'))
+		})
+		It("t4", func() {
+			l, err := NewTableLayoutFromTemplate(`
+				H  H
+				B  B
+				B  B`)
+			Expect(err).Should(BeNil())
+			Expect(l.ShowSideBorder).Should(BeFalse())
+			Expect(l.ShowColumnSeparator).Should(BeFalse())
+			Expect(l.ShowHeaderBottemBorder).Should(BeFalse())
+			tb := NewTable(l)
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data"))
+			tb.AppendRow(1, strShort1)
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			Expect(out).Should(Equal(" ID  Data \n 1   abcd \n"))
+		})
+		It("t5", func() {
+			_, err := NewTableLayoutFromTemplate("| a | b |")
+			Expect(errors.Is(err, ErrInvalidLayoutTemplate)).Should(BeTrue())
+			// every body row is validated
+			_, err = NewTableLayoutFromTemplate("| a | b |\n| 1 | 2 |\n| 1 | 2")
+			Expect(errors.Is(err, ErrInvalidLayoutTemplate)).Should(BeTrue())
+			_, err = NewTableLayoutFromTemplate("| a | b |\n| 1 | 2 |\n: 1 : 2 :")
+			Expect(errors.Is(err, ErrInvalidLayoutTemplate)).Should(BeTrue())
+			_, err = NewTableLayoutFromTemplate("| a | b |\n| 1 | 2 |\n|---|---|\n|---|---|\n|---|---|")
+			Expect(errors.Is(err, ErrInvalidLayoutTemplate)).Should(BeTrue())
+			_, err = NewTableLayoutFromTemplate("| a | b | c |\n| 1 | 2 | 3 |\n| 1 | 2 | 3 |")
+			Expect(errors.Is(err, ErrInvalidLayoutTemplate)).Should(BeTrue())
+			_, err = NewTableLayoutFromTemplate("| a | b |\n| 1 | 2 \n| 1 | 2 |")
+			Expect(errors.Is(err, ErrInvalidLayoutTemplate)).Should(BeTrue())
+			_, err = NewTableLayoutFromTemplate("| a | b |\n|=|\n| 1 | 2 |\n| 1 | 2 |")
+			Expect(errors.Is(err, ErrInvalidLayoutTemplate)).Should(BeTrue())
+		})
+		It("t6", func() {
+			// a 2x2 table with the row separator under the body row
+			l, err := NewTableLayoutFromTemplate(`
+				╔═══╦═══╗
+				║ H ║ H ║
+				╠═══╬═══╣
+				║ B ║ B ║
+				╟───╫───╢
+				╚═══╩═══╝`)
+			Expect(err).Should(BeNil())
+			Expect(l.ShowRowSeparator).Should(BeTrue())
+			Expect([]rune{l.RowSeparatorLeft, l.RowSeparator, l.RowHorizontal, l.RowSeparatorRight}).Should(Equal([]rune("╟╫─╢")))
+			Expect([]rune{l.BodyBottomLeft, l.BodyBottomSeparator, l.BodyBottomRight}).Should(Equal([]rune("╚╩╝")))
+			tb := NewTable(l)
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data"))
+			tb.AppendRow(1, strShort1)
+			tb.AppendRow(2, strSingle)
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				`╔════╦══════╗`,
+				`║ ID ║ Data ║`,
+				`╠════╬══════╣`,
+				`║ 1  ║ abcd ║`,
+				`╟────╫──────╢`,
+				`║ 2  ║ a    ║`,
+				`╚════╩══════╝`,
+				``,
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+
+			l, err = NewTableLayoutFromTemplate("| a | b |\n|---|---|\n| 1 | 2 |")
+			Expect(err).Should(BeNil())
+			Expect(l.ShowRowSeparator).Should(BeFalse())
+			Expect(l.ShowBodyBottomBorder).Should(BeFalse())
+		})
+	})
+
+	Context("validate", func() {
+		It("t1", func() {
+			for _, name := range TableLayoutNames() {
+				l, _ := GetTableLayout(name)
+				Expect(l.Validate()).Should(BeNil())
+			}
+		})
+		It("t2", func() {
+			l := LightTableLayout()
+			l.HeaderSeparator = '丨'
+			err := l.Validate()
+			Expect(errors.Is(err, ErrInvalidLayout)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("HeaderSeparator"))
+			tb := NewTable(l)
+			tb.AppendColumn(test_NewStdColumn("ID"))
+			_, err = tb.Render(Console)
+			Expect(errors.Is(err, ErrInvalidLayout)).Should(BeTrue())
+		})
+		It("t3", func() {
+			// runes of hidden borders are not validated
+			l := LightTableLayout()
+			l.RowHorizontal = 0
+			Expect(l.Validate()).Should(BeNil())
+			l.ShowRowSeparator = true
+			Expect(errors.Is(l.Validate(), ErrInvalidLayout)).Should(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,197 @@
+package gotable
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dataNode is a row of structured outputs, fields are kept in the order of columns
+type dataNode struct {
+	keys     []string
+	values   []any
+	children []*dataNode
+}
+
+func (a *dataNode) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	buf.WriteByte('{')
+	for i, k := range a.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := enc.Encode(k); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		if err := enc.Encode(a.values[i]); err != nil {
+			return nil, err
+		}
+	}
+	if len(a.children) > 0 {
+		if len(a.keys) > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(`"children":`)
+		if err := enc.Encode(a.children); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (a *dataNode) MarshalYAML() (any, error) {
+	n := &yaml.Node{Kind: yaml.MappingNode}
+	for i, k := range a.keys {
+		v := &yaml.Node{}
+		if err := v.Encode(a.values[i]); err != nil {
+			return nil, err
+		}
+		n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: k}, v)
+	}
+	if len(a.children) > 0 {
+		v := &yaml.Node{}
+		if err := v.Encode(a.children); err != nil {
+			return nil, err
+		}
+		n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "children"}, v)
+	}
+	return n, nil
+}
+
+// dataNodes converts rows into structured rows keyed by names of visible columns, rows of trees are nested under the
+// "children" field of their parents. The tree path is replaced by the label field of the tree path style, and markers
+// of hidden children are omitted. A column named "children" is rejected for tables of trees.
+func (a *Table) dataNodes() ([]*dataNode, error) {
+	cols := []int{}
+	keys := []string{}
+	for i, col := range a.columns {
+		if _, ok := col.columnCellMaker.(*TreePathColumn); ok || col.hidden {
+			continue
+		}
+		cols = append(cols, i)
+		keys = append(keys, col.name)
+	}
+	sty := a.treePathStyle()
+	withLabel := sty.Label != "" && a.treePathColumnIndex() != -1 && !slices.Contains(keys, sty.Label)
+	if withLabel {
+		keys = append([]string{sty.Label}, keys...)
+	}
+	if a.treePathColumnIndex() != -1 && slices.Contains(keys, "children") {
+		return nil, fmt.Errorf("%w: children is the field of child nodes", ErrColumnAlreadyExist)
+	}
+
+	var convert func(ns []*rowNode) []*dataNode
+	convert = func(ns []*rowNode) []*dataNode {
+		out := []*dataNode{}
+		for _, n := range ns {
+			if n.row.marker {
+				continue
+			}
+			dn := &dataNode{keys: keys}
+			if withLabel {
+				dn.values = append(dn.values, n.row.label)
+			}
+			for _, i := range cols {
+				dn.values = append(dn.values, n.row.cells[i].Data())
+			}
+			dn.children = convert(n.children)
+			out = append(out, dn)
+		}
+		return out
+	}
+	return convert(a.rowForest()), nil
+}
+
+// renderData renders rows as a JSON or YAML sequence of objects
+func (a *Table) renderData(o Output) (string, error) {
+	nodes, err := a.dataNodes()
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	switch o {
+	case Json:
+		enc := json.NewEncoder(buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(nodes); err != nil {
+			return "", err
+		}
+	case Yaml:
+		enc := yaml.NewEncoder(buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(nodes); err != nil {
+			return "", err
+		}
+		if err := enc.Close(); err != nil {
+			return "", err
+		}
+	}
+	return buf.String(), nil
+}
+
+// renderHtml renders the table as an HTML table, text styles are not rendered. Tables with trees are rendered as
+// treegrids whose rows carry aria-level and aria-expanded, nodes with hidden children are collapsed. Labels of tree rows are indented by their depth.
+func (a *Table) renderHtml() string {
+	tIdx := a.treePathColumnIndex()
+	sb := &strings.Builder{}
+	if tIdx == -1 {
+		sb.WriteString("<table>\n")
+	} else {
+		sb.WriteString("<table role=\"treegrid\">\n")
+	}
+	sb.WriteString("<thead>\n<tr>")
+	for _, col := range a.columns {
+		if !col.hidden {
+			fmt.Fprintf(sb, "<th>%s</th>", htmlText(col.headerText(a.headerTranslator)))
+		}
+	}
+	sb.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for ri, row := range a.rows {
+		sb.WriteString("<tr")
+		if tIdx != -1 {
+			// rows which are not a part of a tree are at the top level of the treegrid
+			fmt.Fprintf(sb, " aria-level=\"%d\"", max(row.depth, 1))
+			if row.id != 0 && ri+1 < len(a.rows) && a.rows[ri+1].parent == row.id {
+				sb.WriteString(" aria-expanded=\"true\"")
+			} else if row.elided != "" && !row.marker {
+				sb.WriteString(" aria-expanded=\"false\"")
+			}
+		}
+		sb.WriteString(">")
+		for ci, col := range a.columns {
+			if col.hidden {
+				continue
+			}
+			if ci != tIdx {
+				fmt.Fprintf(sb, "<td>%s</td>", htmlText(row.cells[ci].String()))
+				continue
+			}
+			text := row.label
+			if row.elided != "" {
+				text = strings.TrimPrefix(text+" "+row.elided, " ")
+			}
+			if row.depth > 1 {
+				fmt.Fprintf(sb, "<td style=\"padding-left: %dem\">%s</td>", row.depth-1, htmlText(text))
+			} else {
+				fmt.Fprintf(sb, "<td>%s</td>", htmlText(text))
+			}
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+	return sb.String()
+}
+
+func htmlText(s string) string {
+	return strings.Replace(html.EscapeString(s), "\n", "<br>", -1)
+}
@@ -0,0 +1,181 @@
+package gotable
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Output Test Suites", func() {
+	test_NewOutputTree := func() *Table {
+		roots, err := BuildTreeFromPaths([]map[string]any{
+			{"path": "src/lib/a.go", "size": 1},
+			{"path": "src/main.go", "size": 2.5},
+			{"path": "go.mod", "size": "<3>"},
+		}, "path", "/", "name")
+		Expect(err).Should(BeNil())
+		tb := NewTable(nil).AllowMissingFields(nil)
+		tb.AppendColumn(test_NewStdColumn("size"))
+		sty := DefaultTreePathStyle()
+		sty.Label = "name"
+		opts := TreeOptions{MaxDepth: 2, ElidedMarker: DefaultElidedMarker}
+		Expect(tb.AppendTreesWithOptions(*sty, opts, roots...)).Should(BeNil())
+		return tb
+	}
+	It("json-case1", func() {
+		out, err := test_NewOutputTree().Render(Json)
+		Expect(err).Should(BeNil())
+		expects := []string{
+			`[`,
+			`  {`,
+			`    "name": "src",`,
+			`    "size": null,`,
+			`    "children": [`,
+			`      {`,
+			`        "name": "lib",`,
+			`        "size": null`,
+			`      },`,
+			`      {`,
+			`        "name": "main.go",`,
+			`        "size": 2.5`,
+			`      }`,
+			`    ]`,
+			`  },`,
+			`  {`,
+			`    "name": "go.mod",`,
+			`    "size": "<3>"`,
+			`  }`,
+			`]`,
+			``,
+		}
+		Expect(strings.Split(out, "\n")).Should(Equal(expects))
+	})
+	It("json-case2", func() {
+		// flat tables are rendered as objects in the order of columns, hidden columns are omitted
+		tb := NewTable(nil)
+		tb.AppendColumn(test_NewStdColumn("b"), test_NewStdColumn("a"), test_NewStdColumn("c").Hidden(true))
+		Expect(tb.AppendRow(1, "x\ny", true)).Should(BeNil())
+		out, err := tb.Render(Json)
+		Expect(err).Should(BeNil())
+		Expect(out).Should(Equal("[\n  {\n    \"b\": 1,\n    \"a\": \"x\\ny\"\n  }\n]\n"))
+
+		out, err = NewTable(nil).Render(Json)
+		Expect(err).Should(BeNil())
+		Expect(out).Should(Equal("[]\n"))
+	})
+	It("json-case3", func() {
+		// the hierarchy is kept by mutators
+		roots, err := BuildTreeFromPaths([]map[string]any{{"path": "a/a1"}, {"path": "b/b1"}}, "path", "/", "name")
+		Expect(err).Should(BeNil())
+		tb := NewTable(nil)
+		tb.AppendColumn(test_NewStdColumn("name"))
+		Expect(tb.AppendTrees(*DefaultTreePathStyle(), roots...)).Should(BeNil())
+		Expect(tb.DeleteRow(2)).Should(BeNil())
+		Expect(tb.InsertRow(1, "", "a0")).Should(BeNil())
+		Expect(tb.AppendRow("", "c")).Should(BeNil())
+		Expect(tb.InsertRow(0, "", "r")).Should(BeNil())
+		Expect(tb.SortBy("name", true)).Should(BeNil())
+		out, err := tb.Render(Json)
+		Expect(err).Should(BeNil())
+		expects := []string{
+			`[`,
+			`  {`,
+			`    "name": "r"`,
+			`  },`,
+			`  {`,
+			`    "name": "c"`,
+			`  },`,
+			`  {`,
+			`    "name": "a",`,
+			`    "children": [`,
+			`      {`,
+			`        "name": "a1"`,
+			`      },`,
+			`      {`,
+			`        "name": "a0"`,
+			`      }`,
+			`    ]`,
+			`  }`,
+			`]`,
+			``,
+		}
+		Expect(strings.Split(out, "\n")).Should(Equal(expects))
+
+		// a column named children is rejected for tables of trees
+		Expect(tb.RenameColumn("name", "children")).Should(BeNil())
+		for _, o := range []Output{Json, Yaml} {
+			_, err = tb.Render(o)
+			Expect(errors.Is(err, ErrColumnAlreadyExist)).Should(BeTrue())
+		}
+	})
+	It("yaml-case1", func() {
+		out, err := test_NewOutputTree().Render(Yaml)
+		Expect(err).Should(BeNil())
+		expects := []string{
+			`- name: src`,
+			`  size: null`,
+			`  children:`,
+			`    - name: lib`,
+			`      size: null`,
+			`    - name: main.go`,
+			`      size: 2.5`,
+			`- name: go.mod`,
+			`  size: <3>`,
+			``,
+		}
+		Expect(strings.Split(out, "\n")).Should(Equal(expects))
+	})
+	It("html-case1", func() {
+		out, err := test_NewOutputTree().Render(Html)
+		Expect(err).Should(BeNil())
+		expects := []string{
+			`<table role="treegrid">`,
+			`<thead>`,
+			`<tr><th>Path</th><th>size</th></tr>`,
+			`</thead>`,
+			`<tbody>`,
+			`<tr aria-level="1" aria-expanded="true"><td>src</td><td>&lt;nil&gt;</td></tr>`,
+			`<tr aria-level="2" aria-expanded="false"><td style="padding-left: 1em">lib (+1 more)</td><td>&lt;nil&gt;</td></tr>`,
+			`<tr aria-level="2"><td style="padding-left: 1em">main.go</td><td>2.5</td></tr>`,
+			`<tr aria-level="1"><td>go.mod</td><td>&lt;3&gt;</td></tr>`,
+			`</tbody>`,
+			`</table>`,
+			``,
+		}
+		Expect(strings.Split(out, "\n")).Should(Equal(expects))
+	})
+	It("html-case2", func() {
+		tb := NewTable(nil)
+		tb.AppendColumn(test_NewStdColumn("a").Subtitle("ms"), test_NewStdColumn("b"))
+		Expect(tb.AppendRow(1, "x\ny")).Should(BeNil())
+		out, err := tb.Render(Html)
+		Expect(err).Should(BeNil())
+		expects := []string{
+			`<table>`,
+			`<thead>`,
+			`<tr><th>a<br>ms</th><th>b</th></tr>`,
+			`</thead>`,
+			`<tbody>`,
+			`<tr><td>1</td><td>x<br>y</td></tr>`,
+			`</tbody>`,
+			`</table>`,
+			``,
+		}
+		Expect(strings.Split(out, "\n")).Should(Equal(expects))
+	})
+	It("html-case3", func() {
+		// rows which are not a part of a tree are at the top level
+		tb := test_NewOutputTree()
+		Expect(tb.AppendRow("", 4)).Should(BeNil())
+		out, err := tb.Render(Html)
+		Expect(err).Should(BeNil())
+		Expect(out).Should(ContainSubstring("<tr aria-level=\"1\"><td></td><td>4</td></tr>\n"))
+		Expect(tb.DeleteRow(0)).Should(BeNil())
+		out, err = tb.Render(Html)
+		Expect(err).Should(BeNil())
+		Expect(strings.Count(out, "<tr aria-level=\"1\">")).Should(Equal(2))
+		Expect(out).ShouldNot(ContainSubstring("aria-level=\"2\""))
+	})
+})
@@ -0,0 +1,440 @@
+package gotable
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;:]*m")
+
+// ParseTable parses a table rendered by Table.Render(Console) back into a table. Layouts are tried in the order of
+// opts.Layout and registered layouts with more borders first, the table uses the matching layout unless opts.Layout
+// is set. Lines of wrapped cells are joined directly when a line fills the cell and with "\n" otherwise, rows are
+// separated by row separators or opts.RowHeights. Styles of texts are discarded.
+func ParseTable(r io.Reader, opts *ImportOptions) (*Table, error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	lines := []string{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		lines = append(lines, strings.TrimRight(ansiEscape.ReplaceAllString(sc.Text(), ""), "\r"))
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: table is empty", ErrInvalidInput)
+	}
+
+	for _, l := range parseCandidates(opts.Layout) {
+		names, records, ok := parseRenderedTable(lines, l, opts.RowHeights)
+		if !ok {
+			continue
+		}
+		tmp := *opts
+		if tmp.Layout == nil {
+			tmp.Layout = l
+		}
+		tb, err := newImportedTable(names, records, &tmp)
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range records {
+			if err := tb.AppendRow(rec...); err != nil {
+				return nil, err
+			}
+		}
+		return tb, nil
+	}
+	return nil, fmt.Errorf("%w: no layout matches the table", ErrInvalidInput)
+}
+
+// parseCandidates returns layouts to try, layouts with more borders are more specific and go first
+func parseCandidates(l *TableLayout) []*TableLayout {
+	out := []*TableLayout{}
+	for _, name := range TableLayoutNames() {
+		tmp, err := GetTableLayout(name)
+		if err == nil {
+			out = append(out, tmp)
+		}
+	}
+	slices.SortStableFunc(out, func(a, b *TableLayout) int {
+		return countBorders(b) - countBorders(a)
+	})
+	if l != nil {
+		out = append([]*TableLayout{l}, out...)
+	}
+	return out
+}
+
+func countBorders(l *TableLayout) int {
+	n := 0
+	for _, b := range []bool{l.ShowHeaderTopBorder, l.ShowHeaderBottemBorder, l.ShowBodyTopBorder, l.ShowBodyBottomBorder,
+		l.ShowSideBorder, l.ShowColumnSeparator, l.ShowRowSeparator} {
+		if b {
+			n++
+		}
+	}
+	return n
+}
+
+// renderedBorder holds the runes of a horizontal border
+type renderedBorder struct {
+	show                         bool
+	left, right, sep, horizontal rune
+}
+
+func renderedBorders(l *TableLayout) (headerTop, headerBottom, bodyTop, bodyBottom, row renderedBorder) {
+	headerTop = renderedBorder{l.ShowHeaderTopBorder, l.HeaderTopLeft, l.HeaderTopRight, l.HeaderTopSeparator, l.HeaderTopHorizontal}
+	headerBottom = renderedBorder{l.ShowHeaderBottemBorder, l.HeaderBottomLeft, l.HeaderBottomRight, l.HeaderBottomSeparator, l.HeaderBottomHorizontal}
+	bodyTop = renderedBorder{l.ShowBodyTopBorder, l.BodyTopLeft, l.BodyTopRight, l.BodyTopSeparator, l.BodyTopHorizontal}
+	bodyBottom = renderedBorder{l.ShowBodyBottomBorder, l.BodyBottomLeft, l.BodyBottomRight, l.BodyBottomSeparator, l.BodyBottomHorizontal}
+	left, right := l.rowSeparatorSides()
+	row = renderedBorder{l.ShowRowSeparator, left, right, l.RowSeparator, l.RowHorizontal}
+	return
+}
+
+// parseRenderedTable splits lines into header names and records following the structure drawn by the layout
+func parseRenderedTable(lines []string, l *TableLayout, heights []int) ([]string, [][]any, bool) {
+	if !l.ShowHeader {
+		return nil, nil, false
+	}
+	headerTop, headerBottom, bodyTop, bodyBottom, rowSep := renderedBorders(l)
+	widths := renderedWidths(lines, l, []renderedBorder{headerTop, headerBottom, bodyTop, bodyBottom, rowSep})
+	if len(widths) == 0 {
+		return nil, nil, false
+	}
+	isBorder := func(i int, b renderedBorder) bool {
+		return i < len(lines) && b.show && strings.TrimRight(lines[i], " ") == strings.TrimRight(renderBorderLine(b, l, widths), " ")
+	}
+
+	p := 0
+	if headerTop.show {
+		if !isBorder(p, headerTop) {
+			return nil, nil, false
+		}
+		p++
+	}
+	header := [][]string{}
+	for p < len(lines) && !isBorder(p, headerBottom) {
+		cells, ok := splitRenderedLine(lines[p], l, widths, l.HeaderLeft, l.HeaderSeparator, l.HeaderRight)
+		if !ok {
+			return nil, nil, false
+		}
+		header = append(header, cells)
+		p++
+		if !headerBottom.show {
+			break
+		}
+	}
+	if len(header) == 0 {
+		return nil, nil, false
+	}
+	if headerBottom.show {
+		if !isBorder(p, headerBottom) {
+			return nil, nil, false
+		}
+		p++
+	}
+	if bodyTop.show {
+		if !isBorder(p, bodyTop) {
+			return nil, nil, false
+		}
+		p++
+	}
+	end := len(lines)
+	if bodyBottom.show {
+		if !isBorder(end-1, bodyBottom) || end-1 < p {
+			return nil, nil, false
+		}
+		end--
+	}
+
+	// group lines of body rows
+	groups := [][][]string{}
+	group := [][]string{}
+	for i := p; i < end; i++ {
+		if isBorder(i, rowSep) {
+			if len(group) == 0 {
+				return nil, nil, false
+			}
+			groups = append(groups, group)
+			group = [][]string{}
+			continue
+		}
+		cells, ok := splitRenderedLine(lines[i], l, widths, l.RowLeft, l.ColumnSeparator, l.RowRight)
+		if !ok {
+			return nil, nil, false
+		}
+		group = append(group, cells)
+		if !rowSep.show && (len(heights) == 0 || len(groups) < len(heights) && len(group) == heights[len(groups)]) {
+			groups = append(groups, group)
+			group = [][]string{}
+		}
+	}
+	if len(group) > 0 {
+		if !rowSep.show {
+			return nil, nil, false
+		}
+		groups = append(groups, group)
+	}
+	if !rowSep.show && len(heights) > 0 && len(groups) != len(heights) {
+		return nil, nil, false
+	}
+
+	all := slices.Clone(header)
+	for _, g := range groups {
+		all = append(all, g...)
+	}
+	names := make([]string, len(widths))
+	paddings := make([][2]int, len(widths))
+	for i := range widths {
+		paddings[i] = renderedPaddings(all, i)
+		names[i] = mergeRenderedCell(header, i, widths[i], paddings[i])
+	}
+	records := make([][]any, len(groups))
+	for ri, g := range groups {
+		records[ri] = make([]any, len(widths))
+		for i := range widths {
+			records[ri][i] = mergeRenderedCell(g, i, widths[i], paddings[i])
+		}
+	}
+	return names, records, true
+}
+
+// renderedPaddings measures the left and the right padding of the i-th column as the narrowest gaps between borders
+// and texts of its cells, the widest text of a column is padded by the paddings only. Columns without texts have no
+// paddings. Spaces at the end of wrapped lines can not be told from the right padding, so that the right padding is
+// not wider than the left one.
+func renderedPaddings(lines [][]string, i int) [2]int {
+	pad := [2]int{-1, -1}
+	for _, cells := range lines {
+		if strings.TrimSpace(cells[i]) == "" {
+			continue
+		}
+		l := len(cells[i]) - len(strings.TrimLeft(cells[i], " "))
+		r := len(cells[i]) - len(strings.TrimRight(cells[i], " "))
+		if pad[0] == -1 || l < pad[0] {
+			pad[0] = l
+		}
+		if pad[1] == -1 || r < pad[1] {
+			pad[1] = r
+		}
+	}
+	return [2]int{max(pad[0], 0), max(min(pad[1], pad[0]), 0)}
+}
+
+// renderedWidths finds widths of columns from the first border line which has column separators. Tables without such
+// lines are split by the separator in the header, or by positions where every line has a space.
+func renderedWidths(lines []string, l *TableLayout, borders []renderedBorder) []int {
+	if l.ShowColumnSeparator {
+		for _, line := range lines {
+			for _, b := range borders {
+				if b.show && b.sep != b.horizontal {
+					if widths := parseBorderLine(line, l, b); widths != nil {
+						return widths
+					}
+				}
+			}
+		}
+		if l.HeaderSeparator != ' ' {
+			rs := []rune(lines[0])
+			if l.ShowSideBorder {
+				if len(rs) < 2 {
+					return nil
+				}
+				rs = rs[1 : len(rs)-1]
+			}
+			widths := []int{}
+			for _, s := range strings.Split(string(rs), string(l.HeaderSeparator)) {
+				widths = append(widths, runewidth.StringWidth(s))
+			}
+			return widths
+		}
+	}
+
+	// positions of columns are inferred from text when separators are blank
+	content := [][]rune{}
+	total := 0
+	for _, line := range lines {
+		rs := []rune(line)
+		if l.ShowSideBorder {
+			if len(rs) < 2 {
+				return nil
+			}
+			rs = rs[1 : len(rs)-1]
+		}
+		content = append(content, rs)
+		total = max(total, len(rs))
+	}
+	spans := fixedWidthSpans(content)
+	if len(spans) == 0 {
+		return nil
+	}
+	sepWidth := 0
+	if l.ShowColumnSeparator {
+		sepWidth = 1
+	}
+	widths := []int{}
+	start := 0
+	for i := range spans {
+		end := total
+		if i < len(spans)-1 {
+			// the padding before the text belongs to the column
+			end = max(spans[i+1].start-1-sepWidth, start)
+		}
+		widths = append(widths, end-start)
+		start = end + sepWidth
+	}
+	return widths
+}
+
+// parseBorderLine returns widths of columns when the line is drawn with runes of the border
+func parseBorderLine(line string, l *TableLayout, b renderedBorder) []int {
+	rs := []rune(line)
+	if l.ShowSideBorder {
+		if len(rs) < 2 || rs[0] != b.left || rs[len(rs)-1] != b.right {
+			return nil
+		}
+		rs = rs[1 : len(rs)-1]
+	}
+	widths := []int{0}
+	for _, r := range rs {
+		switch r {
+		case b.horizontal:
+			widths[len(widths)-1]++
+		case b.sep:
+			if widths[len(widths)-1] == 0 {
+				return nil
+			}
+			widths = append(widths, 0)
+		default:
+			return nil
+		}
+	}
+	if widths[len(widths)-1] == 0 {
+		return nil
+	}
+	return widths
+}
+
+func renderBorderLine(b renderedBorder, l *TableLayout, widths []int) string {
+	parts := []string{}
+	for _, w := range widths {
+		parts = append(parts, strings.Repeat(string(b.horizontal), w))
+	}
+	sep := ""
+	if l.ShowColumnSeparator {
+		sep = string(b.sep)
+	}
+	out := strings.Join(parts, sep)
+	if l.ShowSideBorder {
+		out = string(b.left) + out + string(b.right)
+	}
+	return out
+}
+
+// splitRenderedLine cuts a line into cells by widths of columns. Lines of tables without the right border may have
+// trailing spaces trimmed.
+func splitRenderedLine(line string, l *TableLayout, widths []int, left, sep, right rune) ([]string, bool) {
+	rs := []rune(line)
+	if l.ShowSideBorder {
+		if len(rs) < 2 || rs[0] != left || rs[len(rs)-1] != right {
+			return nil, false
+		}
+		rs = rs[1 : len(rs)-1]
+	}
+	out := []string{}
+	for i, w := range widths {
+		if i > 0 && l.ShowColumnSeparator && len(rs) > 0 {
+			if rs[0] != sep {
+				return nil, false
+			}
+			rs = rs[1:]
+		}
+		n, width := 0, 0
+		for n < len(rs) && width+runewidth.RuneWidth(rs[n]) <= w {
+			width += runewidth.RuneWidth(rs[n])
+			n++
+		}
+		if width != w && (l.ShowSideBorder || n < len(rs)) {
+			return nil, false
+		}
+		out = append(out, string(rs[:n]))
+		rs = rs[n:]
+	}
+	if len(rs) > 0 {
+		return nil, false
+	}
+	return out, true
+}
+
+// mergeRenderedCell joins lines of the i-th cell. Paddings measured by renderedPaddings are cut from lines, and lines
+// which were wrapped continue on the next line without a line feed. Spaces are padded after texts of lines, so that a line is
+// regarded as wrapped when its text fills the width of the content, when it is one column short and the next line
+// starts with a wide character which did not fit, or when the space at the end of the line is the last column of the
+// content. A line of a multi-line cell which is one column shorter than the content is therefore joined with a space.
+func mergeRenderedCell(lines [][]string, i int, w int, pad [2]int) string {
+	pl, pr := pad[0], pad[1]
+	wContent := w - pl - pr
+	texts := []string{}
+	for _, cells := range lines {
+		texts = append(texts, cutRenderedPadding(cells[i], pl, pr))
+	}
+	for len(texts) > 0 && strings.TrimSpace(texts[len(texts)-1]) == "" {
+		texts = texts[:len(texts)-1]
+	}
+	sb := strings.Builder{}
+	wrapped := false
+	for j, t := range texts {
+		if !wrapped {
+			t = strings.TrimLeft(t, " ")
+		}
+		t = strings.TrimRight(t, " ")
+		if j > 0 && !wrapped {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(t)
+
+		wrapped = false
+		if j+1 < len(texts) && wContent > 0 {
+			wText := runewidth.StringWidth(t)
+			next := []rune(texts[j+1])
+			switch {
+			case wText >= wContent:
+				wrapped = true
+			case wText == wContent-1 && len(next) > 0 && runewidth.RuneWidth(next[0]) == 2:
+				wrapped = true
+			case wText == wContent-1 && t != "" && len(next) > 0 && next[0] != ' ':
+				sb.WriteString(" ")
+				wrapped = true
+			}
+		}
+	}
+	return sb.String()
+}
+
+// cutRenderedPadding removes the left and the right padding from a cell, cells narrower than paddings are trimmed
+func cutRenderedPadding(cell string, pl, pr int) string {
+	rs := []rune(cell)
+	if runewidth.StringWidth(cell) < pl+pr || len(rs) < pl+pr {
+		return strings.TrimSpace(cell)
+	}
+	if strings.TrimSpace(string(rs[:pl])) != "" || strings.TrimSpace(string(rs[len(rs)-pr:])) != "" {
+		return strings.TrimSpace(cell)
+	}
+	return string(rs[pl : len(rs)-pr])
+}
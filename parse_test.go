@@ -0,0 +1,125 @@
+package gotable
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func test_RenderForParse(l *TableLayout, rows ...[]any) (*Table, string) {
+	tb := NewTable(l)
+	tb.AppendColumn(NewStandardColumn("ID"), NewStandardColumn("Name"), NewStandardColumn("Note").Width(10, false))
+	for _, row := range rows {
+		Expect(tb.AppendRow(row...)).Should(BeNil())
+	}
+	out, err := tb.Render(Console)
+	Expect(err).Should(BeNil())
+	return tb, out
+}
+
+var _ = Describe("Parse Test Suites", func() {
+	rows := [][]any{
+		{"1", "apple", "fresh"},
+		{"2", "banana", "a long note which is wrapped"},
+		{"3", "cherry", "line1\nline2"},
+	}
+
+	It("parse-case1", func() {
+		// every registered layout with row separators can be parsed back
+		for _, name := range TableLayoutNames() {
+			l, _ := GetTableLayout(name)
+			if !l.ShowColumnSeparator || !l.ShowHeaderBottemBorder {
+				continue
+			}
+			l.ShowRowSeparator = true
+			_, out := test_RenderForParse(l, rows...)
+			tb, err := ParseTable(strings.NewReader(out), &ImportOptions{Layout: l})
+			Expect(err).Should(BeNil(), name)
+			Expect(test_ColumnNames(tb)).Should(Equal([]string{"ID", "Name", "Note"}), name)
+			Expect(tb.RowCount()).Should(Equal(3), name)
+			for i, row := range rows {
+				Expect(tb.Row(i)).Should(Equal(row), name)
+			}
+		}
+	})
+	It("parse-case2", func() {
+		src, out := test_RenderForParse(LightTableLayout(), rows...)
+		tb, err := ParseTable(strings.NewReader(out), nil)
+		Expect(err).Should(BeNil())
+		// every line is a row without row separators
+		Expect(tb.RowCount()).Should(Equal(7))
+		Expect(tb.Layout).Should(Equal(*LightTableLayout()))
+
+		stats, err := src.Stats(Console)
+		Expect(err).Should(BeNil())
+		Expect(stats.RowHeights).Should(Equal([]int{1, 4, 2}))
+		tb, err = ParseTable(strings.NewReader(out), &ImportOptions{RowHeights: stats.RowHeights})
+		Expect(err).Should(BeNil())
+		Expect(tb.RowCount()).Should(Equal(3))
+		Expect(tb.Row(1)).Should(Equal(rows[1]))
+		Expect(tb.Row(2)).Should(Equal(rows[2]))
+
+		_, err = ParseTable(strings.NewReader(out), &ImportOptions{RowHeights: []int{1, 4}})
+		Expect(errors.Is(err, ErrInvalidInput)).Should(BeTrue())
+	})
+	It("parse-case3", func() {
+		// trailing spaces of lines of tables without the right border may be trimmed
+		_, out := test_RenderForParse(BorderlessTableLayout(), rows[0], []any{"2", "banana", ""})
+		lines := strings.Split(out, "\n")
+		for i := range lines {
+			lines[i] = strings.TrimRight(lines[i], " ")
+		}
+		tb, err := ParseTable(strings.NewReader(strings.Join(lines, "\n")), &ImportOptions{Layout: BorderlessTableLayout(), InferTypes: true})
+		Expect(err).Should(BeNil())
+		Expect(test_ColumnNames(tb)).Should(Equal([]string{"ID", "Name", "Note"}))
+		Expect(tb.Row(0)).Should(Equal([]any{int64(1), "apple", "fresh"}))
+		Expect(tb.Row(1)).Should(Equal([]any{int64(2), "banana", ""}))
+	})
+	It("parse-case4", func() {
+		tb, err := ParseTable(strings.NewReader("\n| Key | Value |\n|-----|-------|\n| a   | 1     |\n| b   |       |\n\n"), nil)
+		Expect(err).Should(BeNil())
+		Expect(test_ColumnNames(tb)).Should(Equal([]string{"Key", "Value"}))
+		Expect(tb.Row(1)).Should(Equal([]any{"b", ""}))
+	})
+	It("parse-case5", func() {
+		for _, doc := range []string{"", "\n \n"} {
+			_, err := ParseTable(strings.NewReader(doc), nil)
+			Expect(errors.Is(err, ErrInvalidInput)).Should(BeTrue(), doc)
+		}
+	})
+	It("parse-case6", func() {
+		// spaces and wide characters at the edge of wrapped lines are kept
+		for _, w := range []int{9, 10} {
+			wrapped := []any{"1", "你好世界", "abcdefg hijk"}
+			src := NewTable(LightTableLayout())
+			src.AppendColumn(NewStandardColumn("ID"), NewStandardColumn("Name").Width(7, false), NewStandardColumn("Note").Width(w, false))
+			Expect(src.AppendRow(wrapped...)).Should(BeNil())
+			Expect(src.AppendRow("2", "a\nb", "ab\ncd")).Should(BeNil())
+			out, err := src.Render(Console)
+			Expect(err).Should(BeNil())
+			stats, err := src.Stats(Console)
+			Expect(err).Should(BeNil())
+			parsed, err := ParseTable(strings.NewReader(out), &ImportOptions{RowHeights: stats.RowHeights})
+			Expect(err).Should(BeNil())
+			Expect(parsed.Row(0)).Should(Equal(wrapped), out)
+			Expect(parsed.Row(1)).Should(Equal([]any{"2", "a\nb", "ab\ncd"}), out)
+		}
+	})
+	It("parse-case7", func() {
+		// paddings are measured from the rendered table
+		src := NewTable(LightTableLayout())
+		src.AppendColumn(NewStandardColumn("ID"), NewStandardColumn("Note").Width(10, false).Padding(' ', "   ", "   "))
+		Expect(src.AppendRow("1", "abcd efgh")).Should(BeNil())
+		Expect(src.AppendRow("2", "ab")).Should(BeNil())
+		out, err := src.Render(Console)
+		Expect(err).Should(BeNil())
+		stats, err := src.Stats(Console)
+		Expect(err).Should(BeNil())
+		parsed, err := ParseTable(strings.NewReader(out), &ImportOptions{RowHeights: stats.RowHeights})
+		Expect(err).Should(BeNil())
+		Expect(parsed.Row(0)).Should(Equal([]any{"1", "abcd efgh"}), out)
+		Expect(parsed.Row(1)).Should(Equal([]any{"2", "ab"}), out)
+	})
+})
@@ -0,0 +1,110 @@
+package gotable
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// pathSegment is either a key of a map or a struct field, or an index of a slice or an array
+type pathSegment struct {
+	key   string
+	index int
+	isIdx bool
+}
+
+// parseFieldPath parses paths like "metadata.labels.app" or "spec.containers[0].image"
+func parseFieldPath(p string) ([]pathSegment, error) {
+	out := []pathSegment{}
+	for _, part := range strings.Split(p, ".") {
+		key, rest, found := strings.Cut(part, "[")
+		if key == "" || found && rest == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidFieldPath, p)
+		}
+		out = append(out, pathSegment{key: key})
+		for rest != "" {
+			idx, remain, ok := strings.Cut(rest, "]")
+			if !ok {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidFieldPath, p)
+			}
+			n, err := strconv.Atoi(idx)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidFieldPath, p)
+			}
+			out = append(out, pathSegment{index: n, isIdx: true})
+			if remain == "" {
+				break
+			}
+			if !strings.HasPrefix(remain, "[") {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidFieldPath, p)
+			}
+			rest = remain[1:]
+		}
+	}
+	return out, nil
+}
+
+// lookupFieldPath resolves segments through nested maps, slices, arrays, structs and pointers.
+// Struct fields are matched by the name in the json tag first and then by the field name.
+func lookupFieldPath(v any, segs []pathSegment) (any, bool) {
+	rv := reflect.ValueOf(v)
+	for _, seg := range segs {
+		for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+			if rv.IsNil() {
+				return nil, false
+			}
+			rv = rv.Elem()
+		}
+		switch rv.Kind() {
+		case reflect.Map:
+			if seg.isIdx || rv.Type().Key().Kind() != reflect.String && rv.Type().Key().Kind() != reflect.Interface {
+				return nil, false
+			}
+			key := reflect.ValueOf(seg.key)
+			if rv.Type().Key().Kind() == reflect.String {
+				key = key.Convert(rv.Type().Key())
+			}
+			rv = rv.MapIndex(key)
+		case reflect.Slice, reflect.Array:
+			if !seg.isIdx || seg.index >= rv.Len() {
+				return nil, false
+			}
+			rv = rv.Index(seg.index)
+		case reflect.Struct:
+			if seg.isIdx {
+				return nil, false
+			}
+			rv = structField(rv, seg.key)
+		default:
+			return nil, false
+		}
+		if !rv.IsValid() {
+			return nil, false
+		}
+	}
+	if !rv.IsValid() {
+		return nil, false
+	}
+	return rv.Interface(), true
+}
+
+func structField(rv reflect.Value, name string) reflect.Value {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.IsExported() && tag == name {
+			return rv.Field(i)
+		}
+	}
+	f, ok := t.FieldByName(name)
+	if !ok || !f.IsExported() {
+		return reflect.Value{}
+	}
+	out, err := rv.FieldByIndexErr(f.Index)
+	if err != nil {
+		return reflect.Value{}
+	}
+	return out
+}
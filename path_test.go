@@ -0,0 +1,114 @@
+package gotable
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type testContainer struct {
+	Image string `json:"image"`
+	Ports []int
+}
+
+type testPod struct {
+	Name     string `json:"name"`
+	Labels   map[string]string
+	Spec     *struct{ Containers []testContainer }
+	internal string
+}
+
+var _ = Describe("Path Test Suites", func() {
+	It("parseFieldPath-case1", func() {
+		segs, err := parseFieldPath("spec.containers[0].ports[1][2]")
+		Expect(err).Should(BeNil())
+		Expect(segs).Should(Equal([]pathSegment{
+			{key: "spec"},
+			{key: "containers"},
+			{index: 0, isIdx: true},
+			{key: "ports"},
+			{index: 1, isIdx: true},
+			{index: 2, isIdx: true},
+		}))
+	})
+	It("parseFieldPath-case2", func() {
+		for _, p := range []string{"", "a..b", "a[", "a[x]", "a[-1]", "a[0]b", "[0]", "a.[0]"} {
+			_, err := parseFieldPath(p)
+			Expect(errors.Is(err, ErrInvalidFieldPath)).Should(BeTrue(), p)
+		}
+	})
+	It("lookupFieldPath-case1", func() {
+		doc := map[string]any{}
+		err := json.Unmarshal([]byte(`{"metadata": {"labels": {"app": "web"}}, "spec": {"containers": [{"image": "nginx"}]}}`), &doc)
+		Expect(err).Should(BeNil())
+		segs, _ := parseFieldPath("metadata.labels.app")
+		v, ok := lookupFieldPath(doc, segs)
+		Expect(ok).Should(BeTrue())
+		Expect(v).Should(Equal("web"))
+		segs, _ = parseFieldPath("spec.containers[0].image")
+		v, ok = lookupFieldPath(doc, segs)
+		Expect(ok).Should(BeTrue())
+		Expect(v).Should(Equal("nginx"))
+		segs, _ = parseFieldPath("spec.containers[1].image")
+		_, ok = lookupFieldPath(doc, segs)
+		Expect(ok).Should(BeFalse())
+	})
+	It("lookupFieldPath-case2", func() {
+		pod := &testPod{
+			Name:     "web-1",
+			Labels:   map[string]string{"app": "web"},
+			Spec:     &struct{ Containers []testContainer }{Containers: []testContainer{{Image: "nginx", Ports: []int{80, 443}}}},
+			internal: "x",
+		}
+		for p, expects := range map[string]any{"name": "web-1", "Name": "web-1", "Labels.app": "web", "Spec.Containers[0].image": "nginx", "Spec.Containers[0].Ports[1]": 443} {
+			segs, err := parseFieldPath(p)
+			Expect(err).Should(BeNil())
+			v, ok := lookupFieldPath(pod, segs)
+			Expect(ok).Should(BeTrue(), p)
+			Expect(v).Should(Equal(expects), p)
+		}
+		for _, p := range []string{"internal", "Labels.db", "Spec.Containers.image", "Name[0]"} {
+			segs, _ := parseFieldPath(p)
+			_, ok := lookupFieldPath(pod, segs)
+			Expect(ok).Should(BeFalse(), p)
+		}
+		segs, _ := parseFieldPath("Spec.Containers")
+		_, ok := lookupFieldPath(&testPod{}, segs)
+		Expect(ok).Should(BeFalse())
+	})
+	It("append-case1", func() {
+		tb := NewTable(nil).StrictFields(true)
+		tb.AppendColumn(
+			test_NewStdColumn("Name").FieldPath("metadata.name"),
+			test_NewStdColumn("App").FieldPath("metadata.labels.app"),
+			test_NewStdColumn("Image").FieldPath("spec.containers[0].image"),
+		)
+		doc := map[string]any{}
+		err := json.Unmarshal([]byte(`{"metadata": {"name": "web-1", "labels": {"app": "web"}}, "spec": {"containers": [{"image": "nginx"}]}}`), &doc)
+		Expect(err).Should(BeNil())
+		Expect(tb.AppendRowM(doc)).Should(BeNil())
+		Expect(tb.Row(0)).Should(Equal([]any{"web-1", "web", "nginx"}))
+		doc["status"] = "Running"
+		Expect(errors.Is(tb.AppendRowM(doc), ErrUnknownField)).Should(BeTrue())
+	})
+	It("append-case2", func() {
+		tb := NewTable(nil)
+		tb.AppendColumn(
+			test_NewStdColumn("name"),
+			test_NewStdColumn("Image").FieldPath("Spec.Containers[0].image"),
+			test_NewStdColumn("Port").FieldPath("Spec.Containers[0].Ports[0]"),
+		)
+		pod := testPod{Name: "web-1", Spec: &struct{ Containers []testContainer }{Containers: []testContainer{{Image: "nginx", Ports: []int{80}}}}}
+		Expect(tb.AppendRowS(pod)).Should(BeNil())
+		Expect(tb.Row(0)).Should(Equal([]any{"web-1", "nginx", 80}))
+		err := tb.AppendRowS(testPod{Name: "web-2"})
+		Expect(errors.Is(err, ErrFieldIsMissing)).Should(BeTrue())
+		tb.AllowMissingFields("")
+		Expect(tb.AppendRowS(testPod{Name: "web-2"})).Should(BeNil())
+		Expect(tb.Row(1)).Should(Equal([]any{"web-2", "", ""}))
+		tb.AppendColumn(test_NewStdColumn("Bad").FieldPath("a[b]"))
+		Expect(errors.Is(tb.AppendRowS(pod), ErrInvalidFieldPath)).Should(BeTrue())
+	})
+})
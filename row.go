@@ -6,3 +6,21 @@ type TreeNodeReader interface {
 }
 
 type Row []*Cell
+
+// tableRow is a row of the table with styles and the position of the row in a tree
+type tableRow struct {
+	cells Row
+	style []TextStyle
+	// depth of the row in a tree, roots are at depth 1 and rows which are not a part of a tree are at depth 0
+	depth int
+	// id identifies a row of a tree, rows which are not a part of a tree have no id
+	id int
+	// parent is the id of the row of the parent node, it is 0 for roots and rows which are not a part of a tree
+	parent int
+	// elided is the marker of hidden children shown after the tree path
+	elided string
+	// marker is set on the row which stands for hidden children of its parent
+	marker bool
+	// label is the text shown after the tree path, see TreePathStyle.Label
+	label string
+}
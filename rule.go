@@ -0,0 +1,70 @@
+package gotable
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
+// FormatRule applies text styles to a body cell when the value of the cell matches the rule
+type FormatRule struct {
+	match func(v any) bool
+	text  []TextStyle
+}
+
+// NewFormatRule creates a rule which applies text styles ts when match returns true
+func NewFormatRule(match func(v any) bool, ts ...TextStyle) *FormatRule {
+	return &FormatRule{
+		match: match,
+		text:  ts,
+	}
+}
+
+// WhenGreaterThan matches numeric values greater than n
+func WhenGreaterThan(n float64, ts ...TextStyle) *FormatRule {
+	return NewFormatRule(func(v any) bool {
+		f, ok := toFloat64(v)
+		return ok && f > n
+	}, ts...)
+}
+
+// WhenLessThan matches numeric values less than n
+func WhenLessThan(n float64, ts ...TextStyle) *FormatRule {
+	return NewFormatRule(func(v any) bool {
+		f, ok := toFloat64(v)
+		return ok && f < n
+	}, ts...)
+}
+
+// WhenEqual matches values equal to expected, numeric values are compared regardless of their types
+func WhenEqual(expected any, ts ...TextStyle) *FormatRule {
+	return NewFormatRule(func(v any) bool {
+		f1, ok1 := toFloat64(v)
+		f2, ok2 := toFloat64(expected)
+		if ok1 && ok2 {
+			return f1 == f2
+		}
+		return reflect.DeepEqual(v, expected)
+	}, ts...)
+}
+
+// WhenMatch matches values whose text representation matches the regular expression
+func WhenMatch(re *regexp.Regexp, ts ...TextStyle) *FormatRule {
+	return NewFormatRule(func(v any) bool {
+		return re.MatchString(fmt.Sprintf("%v", v))
+	}, ts...)
+}
+
+func (a *FormatRule) Match(v any) bool {
+	return a.match != nil && a.match(v)
+}
+
+// applyFormatRules returns text styles of the first matching rule, the default styles are returned when nothing matches
+func applyFormatRules(rs []*FormatRule, v any, def []TextStyle) []TextStyle {
+	for _, r := range rs {
+		if r.Match(v) {
+			return r.text
+		}
+	}
+	return def
+}
@@ -0,0 +1,45 @@
+package gotable
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rule Test Suites", func() {
+	It("rule-case1", func() {
+		col := test_NewStdColumn("CPU").Rules(WhenGreaterThan(90, Red, Bold))
+		Expect(col.newCell(95).style).Should(Equal([]TextStyle{Red, Bold}))
+		Expect(col.newCell(90.0).style).Should(BeEmpty())
+		Expect(col.newCell("95").style).Should(BeEmpty())
+	})
+	It("rule-case2", func() {
+		col := test_NewStdColumn("Status").Rules(
+			WhenEqual("FAILED", BgRed),
+			WhenMatch(regexp.MustCompile(`^WARN`), Yellow),
+		)
+		Expect(col.newCell("FAILED").style).Should(Equal([]TextStyle{BgRed}))
+		Expect(col.newCell("WARNING").style).Should(Equal([]TextStyle{Yellow}))
+		Expect(col.newCell("OK").style).Should(BeEmpty())
+	})
+	It("rule-case3", func() {
+		// the first matching rule wins
+		col := test_NewStdColumn("Score").Rules(
+			WhenGreaterThan(90, Green),
+			WhenGreaterThan(60, Yellow),
+			WhenEqual(int64(0), Red),
+		)
+		Expect(col.newCell(uint8(95)).style).Should(Equal([]TextStyle{Green}))
+		Expect(col.newCell(float32(70)).style).Should(Equal([]TextStyle{Yellow}))
+		Expect(col.newCell(0).style).Should(Equal([]TextStyle{Red}))
+	})
+	It("rule-case4", func() {
+		tb := NewTable(nil)
+		tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("CPU").Rules(WhenLessThan(10, Blue)))
+		Expect(tb.AppendRow(1, 5)).Should(BeNil())
+		Expect(tb.AppendRow(2, 50)).Should(BeNil())
+		Expect(tb.Cell(1, 0).style).Should(Equal([]TextStyle{Blue}))
+		Expect(tb.Cell(1, 1).style).Should(BeEmpty())
+	})
+})
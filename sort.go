@@ -0,0 +1,94 @@
+package gotable
+
+import (
+	"fmt"
+	"slices"
+)
+
+// rowNode is a row with rows of its children, it is used to reorder rows of trees
+type rowNode struct {
+	row      tableRow
+	children []*rowNode
+}
+
+// SortBy sorts rows by the value of a column, see compareValues. Rows of trees are sorted among their siblings so that
+// the hierarchy is kept, rows which are not a part of a tree are sorted together with roots. Markers of hidden children
+// stay after their siblings. The sort is stable.
+func (a *Table) SortBy(name string, desc bool) error {
+	cIdx, ok := a.colMap[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrColumnNotExist, name)
+	}
+	var sortNodes func(ns []*rowNode)
+	sortNodes = func(ns []*rowNode) {
+		slices.SortStableFunc(ns, func(x, y *rowNode) int {
+			if x.row.marker != y.row.marker {
+				if x.row.marker {
+					return 1
+				}
+				return -1
+			}
+			n := compareValues(x.row.cells[cIdx].Data(), y.row.cells[cIdx].Data())
+			if desc {
+				return -n
+			}
+			return n
+		})
+		for _, n := range ns {
+			sortNodes(n.children)
+		}
+	}
+	roots := a.rowForest()
+	sortNodes(roots)
+	a.rows = flattenRowForest(roots, nil)
+	a.refreshTreePaths()
+	return nil
+}
+
+// Filter removes rows for which keep returns false, ancestors of kept rows of trees are kept as well so that the
+// hierarchy is shown, e.g. searching processes in a process tree. Markers of hidden children are kept with their parents.
+func (a *Table) Filter(keep func(row map[string]any) bool) {
+	var filter func(ns []*rowNode) []*rowNode
+	filter = func(ns []*rowNode) []*rowNode {
+		out := []*rowNode{}
+		for _, n := range ns {
+			n.children = filter(n.children)
+			if n.row.marker {
+				out = append(out, n)
+				continue
+			}
+			if keep(a.rowMap(n.row)) || slices.ContainsFunc(n.children, func(c *rowNode) bool { return !c.row.marker }) {
+				out = append(out, n)
+			}
+		}
+		return out
+	}
+	a.rows = flattenRowForest(filter(a.rowForest()), nil)
+	a.refreshTreePaths()
+}
+
+// rowForest rebuilds the hierarchy of rows from their parents, rows which are not a part of a tree are roots
+func (a *Table) rowForest() []*rowNode {
+	roots := []*rowNode{}
+	nodes := map[int]*rowNode{}
+	for _, row := range a.rows {
+		n := &rowNode{row: row}
+		if parent, ok := nodes[row.parent]; ok && row.parent != 0 {
+			parent.children = append(parent.children, n)
+		} else {
+			roots = append(roots, n)
+		}
+		if row.id != 0 {
+			nodes[row.id] = n
+		}
+	}
+	return roots
+}
+
+func flattenRowForest(ns []*rowNode, out []tableRow) []tableRow {
+	for _, n := range ns {
+		out = append(out, n.row)
+		out = flattenRowForest(n.children, out)
+	}
+	return out
+}
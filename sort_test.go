@@ -0,0 +1,121 @@
+package gotable
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func test_NewSortTree() *Table {
+	roots, err := BuildTree([]map[string]any{
+		{"id": 1, "name": "init", "cpu": 0.1},
+		{"id": 2, "parent": 1, "name": "sshd", "cpu": 0.2},
+		{"id": 3, "parent": 2, "name": "bash", "cpu": 1.5},
+		{"id": 4, "parent": 1, "name": "nginx", "cpu": 3},
+		{"id": 5, "parent": 4, "name": "worker", "cpu": 2},
+		{"id": 6, "parent": 4, "name": "worker", "cpu": 7},
+		{"id": 7, "parent": 1, "name": "cron", "cpu": 0},
+	}, "id", "parent")
+	Expect(err).Should(BeNil())
+	tb := NewTable(nil)
+	tb.AppendColumn(test_NewStdColumn("id"), test_NewStdColumn("name"), test_NewStdColumn("cpu"))
+	Expect(tb.AppendTrees(*DefaultTreePathStyle().Header(), roots...)).Should(BeNil())
+	return tb
+}
+
+func test_RenderLines(tb *Table) []string {
+	out, err := tb.Render(Console)
+	Expect(err).Should(BeNil())
+	return strings.Split(out, "\n")
+}
+
+var _ = Describe("Sort Test Suites", func() {
+	It("compare-case1", func() {
+		Expect(compareValues(2, 10.5)).Should(Equal(-1))
+		Expect(compareValues(uint8(3), int64(3))).Should(Equal(0))
+		Expect(compareValues("10", "9")).Should(Equal(-1))
+		Expect(compareValues(100, "1")).Should(Equal(-1))
+		t := time.Now()
+		Expect(compareValues(t.Add(time.Second), t)).Should(Equal(1))
+		Expect(compareValues(t, "")).Should(Equal(-1))
+		Expect(compareValues(nil, "")).Should(Equal(0))
+	})
+	It("sort-case1", func() {
+		tb := test_NewSortTree()
+		Expect(tb.SortBy("cpu", true)).Should(BeNil())
+		expects := []string{
+			`+---------+----+--------+-----+`,
+			`|  Path   | id |  name  | cpu |`,
+			`+---------+----+--------+-----+`,
+			`| >-+---- | 1  | init   | 0.1 |`,
+			`|   +-+-- | 4  | nginx  | 3   |`,
+			`|   | +-- | 6  | worker | 7   |`,
+			`|   | \-- | 5  | worker | 2   |`,
+			`|   +-+-- | 2  | sshd   | 0.2 |`,
+			`|   | \-- | 3  | bash   | 1.5 |`,
+			`|   \---- | 7  | cron   | 0   |`,
+			`+---------+----+--------+-----+`,
+			``,
+		}
+		Expect(test_RenderLines(tb)).Should(Equal(expects))
+		Expect(tb.SortBy("name", false)).Should(BeNil())
+		names := []string{}
+		for i := range tb.RowCount() {
+			names = append(names, tb.RowStrings(i)[2])
+		}
+		Expect(names).Should(Equal([]string{"init", "cron", "nginx", "worker", "worker", "sshd", "bash"}))
+		Expect(errors.Is(tb.SortBy("not-exist", false), ErrColumnNotExist)).Should(BeTrue())
+	})
+	It("sort-case2", func() {
+		// markers of hidden children stay after their siblings
+		tb := NewTable(nil)
+		tb.AppendColumn(test_NewStdColumn("ID"))
+		opts := TreeOptions{MaxDepth: 2, ElidedMarker: DefaultElidedMarker, ElidedAsRow: true}
+		Expect(tb.AppendTreesWithOptions(*DefaultTreePathStyle().Header(), opts, test_NewMockTree()...)).Should(BeNil())
+		Expect(tb.SortBy("ID", true)).Should(BeNil())
+		ids := []string{}
+		for i := range tb.RowCount() {
+			ids = append(ids, tb.RowStrings(i)[1])
+		}
+		Expect(ids).Should(Equal([]string{"6", "1", "3", "2", ""}))
+	})
+	It("filter-case1", func() {
+		tb := test_NewSortTree()
+		tb.Filter(func(row map[string]any) bool {
+			return row["name"] == "bash" || row["name"] == "cron"
+		})
+		expects := []string{
+			`+---------+----+------+-----+`,
+			`|  Path   | id | name | cpu |`,
+			`+---------+----+------+-----+`,
+			`| >-+---- | 1  | init | 0.1 |`,
+			`|   +-+-- | 2  | sshd | 0.2 |`,
+			`|   | \-- | 3  | bash | 1.5 |`,
+			`|   \---- | 7  | cron | 0   |`,
+			`+---------+----+------+-----+`,
+			``,
+		}
+		Expect(test_RenderLines(tb)).Should(Equal(expects))
+		tb.Filter(func(row map[string]any) bool {
+			return row["name"] == "init"
+		})
+		Expect(tb.RowCount()).Should(Equal(1))
+		Expect(tb.RowStrings(0)[0]).Should(Equal(">--"))
+	})
+	It("filter-case2", func() {
+		tb := NewTable(nil)
+		tb.AppendColumn(test_NewStdColumn("ID"))
+		for _, id := range []int{3, 1, 2} {
+			tb.AppendRow(id)
+		}
+		Expect(tb.SortBy("ID", false)).Should(BeNil())
+		tb.Filter(func(row map[string]any) bool {
+			return row["ID"] != 2
+		})
+		Expect(tb.Row(0)).Should(Equal([]any{1}))
+		Expect(tb.Row(1)).Should(Equal([]any{3}))
+	})
+})
@@ -0,0 +1,128 @@
+package gotable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// NewTableFromSQL builds a table from query results, a column is created for each result column and numeric
+// columns are right aligned. Rows are not closed by the function.
+func NewTableFromSQL(rows *sql.Rows, opts *ImportOptions) (*Table, error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+	}
+	tb := NewTable(opts.Layout)
+	for _, ct := range types {
+		col := NewStandardColumn(ct.Name())
+		if isNumericSQLType(ct) {
+			col.body.align = AlignRight
+		}
+		if _, err := tb.AppendColumn(col); err != nil {
+			return nil, err
+		}
+	}
+	marker := opts.NullMarker
+	if marker == nil {
+		marker = ""
+	}
+	for (opts.Limit <= 0 || len(tb.rows) < opts.Limit) && rows.Next() {
+		dest := make([]any, len(types))
+		for i, ct := range types {
+			dest[i] = newSQLScanTarget(ct)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+		}
+		vals := make([]any, len(dest))
+		for i, d := range dest {
+			vals[i] = sqlValue(d, marker)
+		}
+		if err := tb.AppendRow(vals...); err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+	}
+	return tb, nil
+}
+
+// newSQLScanTarget creates a pointer of the scan type reported by the driver, values of unknown types are scanned as any
+func newSQLScanTarget(ct *sql.ColumnType) any {
+	t := ct.ScanType()
+	if t == nil || t.Kind() == reflect.Interface || t == reflect.TypeOf(sql.RawBytes{}) {
+		return new(any)
+	}
+	if nullable, ok := ct.Nullable(); ok && !nullable || t.Kind() == reflect.Pointer || reflect.PointerTo(t).Implements(reflect.TypeOf((*sql.Scanner)(nil)).Elem()) {
+		return reflect.New(t).Interface()
+	}
+	// nullable columns of plain types are scanned through a pointer, which is set to nil for NULL
+	return reflect.New(reflect.PointerTo(t)).Interface()
+}
+
+// sqlValue dereferences a scan target, NULL values are replaced by the marker
+func sqlValue(dest any, marker any) any {
+	v := reflect.ValueOf(dest).Elem().Interface()
+	if valuer, ok := v.(driver.Valuer); ok {
+		dv, err := valuer.Value()
+		if err != nil || dv == nil {
+			return marker
+		}
+		v = dv
+	}
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() && rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return marker
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return marker
+	}
+	if b, ok := rv.Interface().([]byte); ok {
+		return string(b)
+	}
+	return rv.Interface()
+}
+
+var numericSQLTypes = map[string]bool{
+	"INT": true, "INTEGER": true, "TINYINT": true, "SMALLINT": true, "MEDIUMINT": true, "BIGINT": true,
+	"INT2": true, "INT4": true, "INT8": true, "SERIAL": true, "SMALLSERIAL": true, "BIGSERIAL": true,
+	"DECIMAL": true, "NUMERIC": true, "NUMBER": true, "MONEY": true,
+	"FLOAT": true, "FLOAT4": true, "FLOAT8": true, "REAL": true, "DOUBLE": true, "DOUBLE PRECISION": true,
+}
+
+// isNumericSQLType tells whether a result column holds numbers by the database type name, or by the scan type when
+// the driver does not report the type name
+func isNumericSQLType(ct *sql.ColumnType) bool {
+	name := strings.ToUpper(ct.DatabaseTypeName())
+	name, _, _ = strings.Cut(name, "(")
+	name = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(name), "UNSIGNED"))
+	if name != "" {
+		return numericSQLTypes[name]
+	}
+	t := ct.ScanType()
+	if t == nil {
+		return false
+	}
+	switch t {
+	case reflect.TypeOf(sql.NullInt64{}), reflect.TypeOf(sql.NullInt32{}), reflect.TypeOf(sql.NullInt16{}),
+		reflect.TypeOf(sql.NullByte{}), reflect.TypeOf(sql.NullFloat64{}):
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
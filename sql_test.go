@@ -0,0 +1,140 @@
+package gotable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeSQLColumn describes a result column of the fake driver
+type fakeSQLColumn struct {
+	name     string
+	typeName string
+	scanType reflect.Type
+	nullable bool
+}
+
+var fakeSQLColumns = []fakeSQLColumn{
+	{"id", "BIGINT", reflect.TypeOf(int64(0)), false},
+	{"name", "VARCHAR", reflect.TypeOf(""), true},
+	{"price", "DECIMAL(10,2)", reflect.TypeOf(sql.NullFloat64{}), true},
+	{"note", "", reflect.TypeOf([]byte{}), true},
+}
+
+var fakeSQLData = [][]driver.Value{
+	{int64(1), "apple", 1.5, []byte("fresh")},
+	{int64(2), nil, nil, nil},
+	{int64(3), "cherry", 12.25, []byte("")},
+}
+
+type fakeSQLDriver struct{}
+
+func (fakeSQLDriver) Open(string) (driver.Conn, error) { return fakeSQLConn{}, nil }
+
+type fakeSQLConn struct{}
+
+func (fakeSQLConn) Prepare(q string) (driver.Stmt, error) { return fakeSQLStmt{q}, nil }
+func (fakeSQLConn) Close() error                          { return nil }
+func (fakeSQLConn) Begin() (driver.Tx, error)             { return nil, errors.New("not supported") }
+
+type fakeSQLStmt struct{ q string }
+
+func (fakeSQLStmt) Close() error  { return nil }
+func (fakeSQLStmt) NumInput() int { return 0 }
+func (fakeSQLStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (a fakeSQLStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeSQLRows{fail: a.q == "fail"}, nil
+}
+
+type fakeSQLRows struct {
+	i    int
+	fail bool
+}
+
+func (a *fakeSQLRows) Columns() []string {
+	out := []string{}
+	for _, c := range fakeSQLColumns {
+		out = append(out, c.name)
+	}
+	return out
+}
+func (a *fakeSQLRows) Close() error { return nil }
+func (a *fakeSQLRows) Next(dest []driver.Value) error {
+	if a.fail && a.i == 1 {
+		return errors.New("connection lost")
+	}
+	if a.i >= len(fakeSQLData) {
+		return io.EOF
+	}
+	copy(dest, fakeSQLData[a.i])
+	a.i++
+	return nil
+}
+func (a *fakeSQLRows) ColumnTypeDatabaseTypeName(i int) string { return fakeSQLColumns[i].typeName }
+func (a *fakeSQLRows) ColumnTypeScanType(i int) reflect.Type   { return fakeSQLColumns[i].scanType }
+func (a *fakeSQLRows) ColumnTypeNullable(i int) (bool, bool)   { return fakeSQLColumns[i].nullable, true }
+
+func init() {
+	sql.Register("gotable-fake", fakeSQLDriver{})
+}
+
+var _ = Describe("SQL Test Suites", func() {
+	var db *sql.DB
+	BeforeEach(func() {
+		var err error
+		db, err = sql.Open("gotable-fake", "")
+		Expect(err).Should(BeNil())
+	})
+	AfterEach(func() {
+		db.Close()
+	})
+
+	It("sql-case1", func() {
+		rows, err := db.Query("select")
+		Expect(err).Should(BeNil())
+		defer rows.Close()
+		tb, err := NewTableFromSQL(rows, &ImportOptions{Layout: MarkdownTableLayout(), NullMarker: "NULL"})
+		Expect(err).Should(BeNil())
+		Expect(tb.RowCount()).Should(Equal(3))
+		Expect(tb.Row(0)).Should(Equal([]any{int64(1), "apple", 1.5, "fresh"}))
+		Expect(tb.Row(1)).Should(Equal([]any{int64(2), "NULL", "NULL", "NULL"}))
+		for _, col := range tb.Columns() {
+			col.HeaderStyle(DefauleHeaderStyle().Text())
+		}
+		out, err := tb.Render(Console)
+		Expect(err).Should(BeNil())
+		expects := []string{
+			"| id |  name  | price | note  |",
+			"|----|--------|-------|-------|",
+			"|  1 | apple  |   1.5 | fresh |",
+			"|  2 | NULL   |  NULL | NULL  |",
+			"|  3 | cherry | 12.25 |       |",
+			"",
+		}
+		Expect(strings.Split(out, "\n")).Should(Equal(expects))
+	})
+	It("sql-case2", func() {
+		rows, err := db.Query("select")
+		Expect(err).Should(BeNil())
+		defer rows.Close()
+		tb, err := NewTableFromSQL(rows, &ImportOptions{Limit: 2})
+		Expect(err).Should(BeNil())
+		Expect(tb.RowCount()).Should(Equal(2))
+		Expect(tb.Row(1)).Should(Equal([]any{int64(2), "", "", ""}))
+	})
+	It("sql-case3", func() {
+		rows, err := db.Query("fail")
+		Expect(err).Should(BeNil())
+		defer rows.Close()
+		_, err = NewTableFromSQL(rows, nil)
+		Expect(errors.Is(err, ErrInvalidInput)).Should(BeTrue())
+	})
+})
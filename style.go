@@ -1,6 +1,8 @@
 package gotable
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -33,6 +35,11 @@ type TableLayout struct {
 	ColumnPaddingRight     string
 	CellPadding            string
 
+	// RowSeparatorLeft and RowSeparatorRight are the sides of row separators, RowLeft and RowRight are used when they
+	// are not set
+	RowSeparatorLeft  rune
+	RowSeparatorRight rune
+
 	ShowHeader             bool
 	ShowHeaderTopBorder    bool
 	ShowHeaderBottemBorder bool
@@ -42,6 +49,9 @@ type TableLayout struct {
 	ShowColumnSeparator    bool
 	ShowRowSeparator       bool
 
+	// StripeStyle is applied to every other body row
+	StripeStyle []TextStyle
+
 	Width int
 }
 
@@ -81,6 +91,24 @@ func (a *TableLayout) SplitHeaderAndBody() *TableLayout {
 	return a
 }
 
+// StripeRows applies text styles to every other body row to make wide tables easier to scan
+func (a *TableLayout) StripeRows(tss ...TextStyle) *TableLayout {
+	a.StripeStyle = tss
+	return a
+}
+
+// rowSeparatorSides returns the left and the right rune of row separators
+func (a *TableLayout) rowSeparatorSides() (rune, rune) {
+	left, right := a.RowSeparatorLeft, a.RowSeparatorRight
+	if left == 0 {
+		left = a.RowLeft
+	}
+	if right == 0 {
+		right = a.RowRight
+	}
+	return left, right
+}
+
 func LightTableLayout() *TableLayout {
 	return &TableLayout{
 		HeaderTopLeft:          '┌',
@@ -161,6 +189,220 @@ func DefaultTableLayout() *TableLayout {
 	}
 }
 
+func DoubleTableLayout() *TableLayout {
+	return &TableLayout{
+		HeaderTopLeft:          '╔',
+		HeaderTopRight:         '╗',
+		HeaderTopSeparator:     '╦',
+		HeaderTopHorizontal:    '═',
+		HeaderLeft:             '║',
+		HeaderRight:            '║',
+		HeaderSeparator:        '║',
+		HeaderBottomLeft:       '╠',
+		HeaderBottomRight:      '╣',
+		HeaderBottomSeparator:  '╬',
+		HeaderBottomHorizontal: '═',
+		BodyTopLeft:            '╔',
+		BodyTopRight:           '╗',
+		BodyTopSeparator:       '╦',
+		BodyTopHorizontal:      '═',
+		BodyBottomLeft:         '╚',
+		BodyBottomRight:        '╝',
+		BodyBottomSeparator:    '╩',
+		BodyBottomHorizontal:   '═',
+		RowLeft:                '║',
+		RowRight:               '║',
+		RowSeparator:           '╬',
+		RowHorizontal:          '═',
+		ColumnSeparator:        '║',
+		ColumnPaddingLeft:      " ",
+		ColumnPaddingRight:     " ",
+		CellPadding:            " ",
+		ShowHeader:             true,
+		ShowHeaderTopBorder:    true,
+		ShowHeaderBottemBorder: true,
+		ShowBodyTopBorder:      false,
+		ShowBodyBottomBorder:   true,
+		ShowSideBorder:         true,
+		ShowColumnSeparator:    true,
+		ShowRowSeparator:       false,
+	}
+}
+
+func RoundedTableLayout() *TableLayout {
+	l := LightTableLayout()
+	l.HeaderTopLeft = '╭'
+	l.HeaderTopRight = '╮'
+	l.BodyTopLeft = '╭'
+	l.BodyTopRight = '╮'
+	l.BodyBottomLeft = '╰'
+	l.BodyBottomRight = '╯'
+	return l
+}
+
+func HeavyTableLayout() *TableLayout {
+	return &TableLayout{
+		HeaderTopLeft:          '┏',
+		HeaderTopRight:         '┓',
+		HeaderTopSeparator:     '┳',
+		HeaderTopHorizontal:    '━',
+		HeaderLeft:             '┃',
+		HeaderRight:            '┃',
+		HeaderSeparator:        '┃',
+		HeaderBottomLeft:       '┣',
+		HeaderBottomRight:      '┫',
+		HeaderBottomSeparator:  '╋',
+		HeaderBottomHorizontal: '━',
+		BodyTopLeft:            '┏',
+		BodyTopRight:           '┓',
+		BodyTopSeparator:       '┳',
+		BodyTopHorizontal:      '━',
+		BodyBottomLeft:         '┗',
+		BodyBottomRight:        '┛',
+		BodyBottomSeparator:    '┻',
+		BodyBottomHorizontal:   '━',
+		RowLeft:                '┃',
+		RowRight:               '┃',
+		RowSeparator:           '╋',
+		RowHorizontal:          '━',
+		ColumnSeparator:        '┃',
+		ColumnPaddingLeft:      " ",
+		ColumnPaddingRight:     " ",
+		CellPadding:            " ",
+		ShowHeader:             true,
+		ShowHeaderTopBorder:    true,
+		ShowHeaderBottemBorder: true,
+		ShowBodyTopBorder:      false,
+		ShowBodyBottomBorder:   true,
+		ShowSideBorder:         true,
+		ShowColumnSeparator:    true,
+		ShowRowSeparator:       false,
+	}
+}
+
+// HeavyHeaderTableLayout draws the header with heavy lines and the body with light lines
+func HeavyHeaderTableLayout() *TableLayout {
+	l := LightTableLayout()
+	l.HeaderTopLeft = '┏'
+	l.HeaderTopRight = '┓'
+	l.HeaderTopSeparator = '┳'
+	l.HeaderTopHorizontal = '━'
+	l.HeaderLeft = '┃'
+	l.HeaderRight = '┃'
+	l.HeaderSeparator = '┃'
+	l.HeaderBottomLeft = '┡'
+	l.HeaderBottomRight = '┩'
+	l.HeaderBottomSeparator = '╇'
+	l.HeaderBottomHorizontal = '━'
+	return l
+}
+
+func DottedTableLayout() *TableLayout {
+	l := LightTableLayout()
+	l.HeaderTopHorizontal = '┄'
+	l.HeaderLeft = '┆'
+	l.HeaderRight = '┆'
+	l.HeaderSeparator = '┆'
+	l.HeaderBottomHorizontal = '┄'
+	l.BodyTopHorizontal = '┄'
+	l.BodyBottomHorizontal = '┄'
+	l.RowLeft = '┆'
+	l.RowRight = '┆'
+	l.RowHorizontal = '┄'
+	l.ColumnSeparator = '┆'
+	return l
+}
+
+// CompactTableLayout draws an ASCII table without outer borders
+func CompactTableLayout() *TableLayout {
+	return &TableLayout{
+		HeaderTopLeft:          ' ',
+		HeaderTopRight:         ' ',
+		HeaderTopSeparator:     ' ',
+		HeaderTopHorizontal:    '-',
+		HeaderLeft:             ' ',
+		HeaderRight:            ' ',
+		HeaderSeparator:        ' ',
+		HeaderBottomLeft:       ' ',
+		HeaderBottomRight:      ' ',
+		HeaderBottomSeparator:  ' ',
+		HeaderBottomHorizontal: '-',
+		BodyTopLeft:            ' ',
+		BodyTopRight:           ' ',
+		BodyTopSeparator:       ' ',
+		BodyTopHorizontal:      '-',
+		BodyBottomLeft:         ' ',
+		BodyBottomRight:        ' ',
+		BodyBottomSeparator:    ' ',
+		BodyBottomHorizontal:   '-',
+		RowLeft:                ' ',
+		RowRight:               ' ',
+		RowSeparator:           ' ',
+		RowHorizontal:          '-',
+		ColumnSeparator:        ' ',
+		ColumnPaddingLeft:      " ",
+		ColumnPaddingRight:     " ",
+		CellPadding:            " ",
+		ShowHeader:             true,
+		ShowHeaderTopBorder:    false,
+		ShowHeaderBottemBorder: true,
+		ShowBodyTopBorder:      false,
+		ShowBodyBottomBorder:   false,
+		ShowSideBorder:         false,
+		ShowColumnSeparator:    true,
+		ShowRowSeparator:       false,
+	}
+}
+
+// BorderlessTableLayout aligns columns with spaces only, like the output of "column -t"
+func BorderlessTableLayout() *TableLayout {
+	l := CompactTableLayout()
+	l.ShowHeaderBottemBorder = false
+	l.ShowColumnSeparator = false
+	return l
+}
+
+// MarkdownTableLayout draws tables in the pipe table syntax of markdown
+func MarkdownTableLayout() *TableLayout {
+	return &TableLayout{
+		HeaderTopLeft:          '|',
+		HeaderTopRight:         '|',
+		HeaderTopSeparator:     '|',
+		HeaderTopHorizontal:    '-',
+		HeaderLeft:             '|',
+		HeaderRight:            '|',
+		HeaderSeparator:        '|',
+		HeaderBottomLeft:       '|',
+		HeaderBottomRight:      '|',
+		HeaderBottomSeparator:  '|',
+		HeaderBottomHorizontal: '-',
+		BodyTopLeft:            '|',
+		BodyTopRight:           '|',
+		BodyTopSeparator:       '|',
+		BodyTopHorizontal:      '-',
+		BodyBottomLeft:         '|',
+		BodyBottomRight:        '|',
+		BodyBottomSeparator:    '|',
+		BodyBottomHorizontal:   '-',
+		RowLeft:                '|',
+		RowRight:               '|',
+		RowSeparator:           '|',
+		RowHorizontal:          '-',
+		ColumnSeparator:        '|',
+		ColumnPaddingLeft:      " ",
+		ColumnPaddingRight:     " ",
+		CellPadding:            " ",
+		ShowHeader:             true,
+		ShowHeaderTopBorder:    false,
+		ShowHeaderBottemBorder: true,
+		ShowBodyTopBorder:      false,
+		ShowBodyBottomBorder:   false,
+		ShowSideBorder:         true,
+		ShowColumnSeparator:    true,
+		ShowRowSeparator:       false,
+	}
+}
+
 type TreePathStyle struct {
 	Name          string
 	Root          string
@@ -171,9 +413,30 @@ type TreePathStyle struct {
 	Children      string
 	PrefixLeveled string
 	PrefixBlank   string
+	// Label is the field of nodes shown after the tree path, long labels are wrapped under the path when the overflow
+	// action is Wordwrap, see Overflow
+	Label string
+	// Indent is the width of the tree path of each level, glyphs are padded or cut to the width. 2 is used when it is 0.
+	Indent int
+	// LabelInPath puts the label right after the connector of the node like the tree command, instead of after the
+	// tree path padded to the deepest level. Roots take no space when Root is empty.
+	LabelInPath bool
+	// DepthStyles colors rows of each depth like Table.RowStyle, roots use the first style and styles are reused for
+	// deeper rows. Styles set by Table.RowStyle are applied on top of them.
+	DepthStyles []TextStyle
 
-	header []TextStyle
-	body   []TextStyle
+	header      []TextStyle
+	body        []TextStyle
+	overflow    ColumnOverFlowAction
+	hasOverflow bool
+}
+
+// Overflow sets the overflow action of labels which do not fit the width of the column and lets the column shrink to
+// the width of the table. Tree path columns keep their width and reject long labels when it is not set. The tree path
+// itself is never wrapped or truncated.
+func (a *TreePathStyle) Overflow(ofa ColumnOverFlowAction) *TreePathStyle {
+	a.overflow, a.hasOverflow = ofa, true
+	return a
 }
 
 func (a *TreePathStyle) Header(ss ...TextStyle) *TreePathStyle {
@@ -186,15 +449,49 @@ func (a *TreePathStyle) Body(ss ...TextStyle) *TreePathStyle {
 	return a
 }
 
+// indent returns the width of the tree path of each level
+func (a TreePathStyle) indent() int {
+	if a.Indent > 0 {
+		return a.Indent
+	}
+	return 2
+}
+
+// segment pads s with pad or cuts it to the indent
+func (a TreePathStyle) segment(s string, pad string) string {
+	rs := []rune(s)
+	n := a.indent()
+	if len(rs) >= n {
+		return string(rs[:n])
+	}
+	return s + strings.Repeat(pad, n-len(rs))
+}
+
 func (a TreePathStyle) ReplacePathAsExtention(s string) string {
-	out := strings.Replace(s, a.Children, a.PrefixLeveled, -1)
-	out = strings.Replace(out, a.Middle, a.PrefixLeveled, -1)
-	out = strings.Replace(out, a.Terminal, a.PrefixBlank, -1)
-	out = strings.Replace(out, a.Root, a.PrefixBlank, -1)
-	out = strings.Replace(out, a.PadLine, a.PadBlank, -1)
+	out := s
+	for _, r := range [][2]string{
+		{a.Children, a.PrefixLeveled},
+		{a.Middle, a.PrefixLeveled},
+		{a.Terminal, a.PrefixBlank},
+		{a.Root, a.PrefixBlank},
+		{a.PadLine, a.PadBlank},
+	} {
+		// empty glyphs would match between every character
+		if r[0] != "" {
+			out = strings.Replace(out, r[0], r[1], -1)
+		}
+	}
 	return out
 }
 
+// depthStyle returns the text style of rows at the depth
+func (a TreePathStyle) depthStyle(depth int) []TextStyle {
+	if len(a.DepthStyles) == 0 || depth < 1 {
+		return nil
+	}
+	return []TextStyle{a.DepthStyles[(depth-1)%len(a.DepthStyles)]}
+}
+
 func DefaultTreePathStyle() *TreePathStyle {
 	return &TreePathStyle{
 		Name:          "Path",
@@ -226,3 +523,160 @@ func LightTreePathStyle() *TreePathStyle {
 		body:          []TextStyle{},
 	}
 }
+
+func HeavyTreePathStyle() *TreePathStyle {
+	return &TreePathStyle{
+		Name:          "Path",
+		Root:          "■━",
+		Middle:        "┣━",
+		Terminal:      "┗━",
+		Children:      "┳━",
+		PrefixLeveled: "┃ ",
+		PrefixBlank:   "  ",
+		PadLine:       "━",
+		PadBlank:      " ",
+		header:        []TextStyle{},
+		body:          []TextStyle{},
+	}
+}
+
+func DoubleTreePathStyle() *TreePathStyle {
+	return &TreePathStyle{
+		Name:          "Path",
+		Root:          "□═",
+		Middle:        "╠═",
+		Terminal:      "╚═",
+		Children:      "╦═",
+		PrefixLeveled: "║ ",
+		PrefixBlank:   "  ",
+		PadLine:       "═",
+		PadBlank:      " ",
+		header:        []TextStyle{},
+		body:          []TextStyle{},
+	}
+}
+
+func RoundedTreePathStyle() *TreePathStyle {
+	sty := LightTreePathStyle()
+	sty.Root = "○─"
+	sty.Terminal = "╰─"
+	return sty
+}
+
+// TreeCommandTreePathStyle draws trees like the tree command, labels follow the connectors of nodes
+func TreeCommandTreePathStyle() *TreePathStyle {
+	return &TreePathStyle{
+		Name:          "Path",
+		Root:          "",
+		Middle:        "├──",
+		Terminal:      "└──",
+		Children:      "",
+		PrefixLeveled: "│",
+		PrefixBlank:   "",
+		PadLine:       " ",
+		PadBlank:      " ",
+		Indent:        4,
+		LabelInPath:   true,
+		header:        []TextStyle{},
+		body:          []TextStyle{},
+	}
+}
+
+// IndentTreePathStyle shows the hierarchy by indenting labels only
+func IndentTreePathStyle() *TreePathStyle {
+	return &TreePathStyle{
+		Name:        "Path",
+		PadLine:     " ",
+		PadBlank:    " ",
+		Indent:      2,
+		LabelInPath: true,
+		header:      []TextStyle{},
+		body:        []TextStyle{},
+	}
+}
+
+// text styles with parameters are encoded into the bits above textStylePayloadMask
+const (
+	textStylePayloadMask TextStyle = 1<<24 - 1
+	textStyleFg256       TextStyle = 1 << 24
+	textStyleBg256       TextStyle = 2 << 24
+	textStyleFgRGB       TextStyle = 3 << 24
+	textStyleBgRGB       TextStyle = 4 << 24
+)
+
+// SGR parameter groups, styles in the same group override each other
+const (
+	sgrAttribute = iota
+	sgrForeground
+	sgrBackground
+)
+
+// Color256 returns a foreground color of the 256-color palette
+func Color256(n uint8) TextStyle {
+	return textStyleFg256 | TextStyle(n)
+}
+
+// BgColor256 returns a background color of the 256-color palette
+func BgColor256(n uint8) TextStyle {
+	return textStyleBg256 | TextStyle(n)
+}
+
+// RGB returns a 24-bit foreground color
+func RGB(r, g, b uint8) TextStyle {
+	return textStyleFgRGB | TextStyle(r)<<16 | TextStyle(g)<<8 | TextStyle(b)
+}
+
+// BgRGB returns a 24-bit background color
+func BgRGB(r, g, b uint8) TextStyle {
+	return textStyleBgRGB | TextStyle(r)<<16 | TextStyle(g)<<8 | TextStyle(b)
+}
+
+// sgr returns the SGR parameters to turn the style on and off, and the group it belongs to
+func (a TextStyle) sgr() (string, string, int, bool) {
+	payload := a & textStylePayloadMask
+	rgb := func() string {
+		return fmt.Sprintf("%d;%d;%d", int(payload>>16&0xff), int(payload>>8&0xff), int(payload&0xff))
+	}
+	switch a &^ textStylePayloadMask {
+	case textStyleFg256:
+		return fmt.Sprintf("38;5;%d", int(payload&0xff)), "39", sgrForeground, true
+	case textStyleBg256:
+		return fmt.Sprintf("48;5;%d", int(payload&0xff)), "49", sgrBackground, true
+	case textStyleFgRGB:
+		return "38;2;" + rgb(), "39", sgrForeground, true
+	case textStyleBgRGB:
+		return "48;2;" + rgb(), "49", sgrBackground, true
+	}
+	switch {
+	case a >= Red && a <= Blue:
+		return strconv.Itoa(int(31 + a - Red)), "39", sgrForeground, true
+	case a >= BgRed && a <= BgBlue:
+		return strconv.Itoa(int(41 + a - BgRed)), "49", sgrBackground, true
+	case a >= Black && a <= White:
+		return strconv.Itoa([]int{30, 35, 36, 37}[a-Black]), "39", sgrForeground, true
+	case a >= BgBlack && a <= BgWhite:
+		return strconv.Itoa([]int{40, 45, 46, 47}[a-BgBlack]), "49", sgrBackground, true
+	case a >= BrightBlack && a <= BrightWhite:
+		return strconv.Itoa(int(90 + a - BrightBlack)), "39", sgrForeground, true
+	case a >= BgBrightBlack && a <= BgBrightWhite:
+		return strconv.Itoa(int(100 + a - BgBrightBlack)), "49", sgrBackground, true
+	}
+	switch a {
+	case Bold:
+		return "1", "22", sgrAttribute, true
+	case Dim:
+		return "2", "22", sgrAttribute, true
+	case Italic:
+		return "3", "23", sgrAttribute, true
+	case Underline:
+		return "4", "24", sgrAttribute, true
+	case Blink:
+		return "5", "25", sgrAttribute, true
+	case Reverse:
+		return "7", "27", sgrAttribute, true
+	case Strikethrough:
+		return "9", "29", sgrAttribute, true
+	default:
+		return "", "", 0, false
+	}
+}
@@ -2,6 +2,9 @@ package gotable
 
 import (
 	"fmt"
+	"iter"
+	"maps"
+	"slices"
 	"strings"
 	"unicode/utf8"
 )
@@ -9,10 +12,15 @@ import (
 type Table struct {
 	Layout TableLayout
 
-	columns []*Column
-	rows    []Row
-	stats   TableStats
-	colMap  map[string]int
+	columns          []*Column
+	rows             []tableRow
+	colMap           map[string]int
+	headerTranslator func(string) string
+	allowMissing     bool
+	placeholder      any
+	strictFields     bool
+	// lastRowID is the id of the latest row of trees, see Row.id
+	lastRowID int
 }
 
 type TableStats struct {
@@ -21,6 +29,15 @@ type TableStats struct {
 	HeaderHeight int
 }
 
+// renderPlan is the layout computed by a single call of Render, width limits of columns are adjusted on the plan instead
+// of the columns so that rendering leaves the table unchanged and a table can be rendered concurrently
+type renderPlan struct {
+	o           Output
+	layout      TableLayout
+	widthLimits []int
+	stats       TableStats
+}
+
 func NewTable(l *TableLayout) *Table {
 	var tmp *TableLayout
 	if l != nil {
@@ -31,12 +48,33 @@ func NewTable(l *TableLayout) *Table {
 	t := &Table{
 		Layout:  *tmp,
 		columns: []*Column{},
-		rows:    []Row{},
+		rows:    []tableRow{},
 		colMap:  map[string]int{},
 	}
 	return t
 }
 
+// HeaderTranslator sets a lookup function which translates titles and subtitles of columns when rendering the header,
+// the original text is kept when the function returns an empty string
+func (a *Table) HeaderTranslator(f func(string) string) *Table {
+	a.headerTranslator = f
+	return a
+}
+
+// AllowMissingFields makes rows built from fields tolerate missing fields, placeholder is used for columns without
+// default values. Use an empty string to treat missing fields as empty.
+func (a *Table) AllowMissingFields(placeholder any) *Table {
+	a.allowMissing = true
+	a.placeholder = placeholder
+	return a
+}
+
+// StrictFields makes rows built from fields fail when a field does not belong to any column
+func (a *Table) StrictFields(b bool) *Table {
+	a.strictFields = b
+	return a
+}
+
 func (a *Table) AppendColumn(cs ...*Column) (*Table, error) {
 	for _, c := range cs {
 		_, err := a.appendColumn(c)
@@ -49,7 +87,17 @@ func (a *Table) AppendColumn(cs ...*Column) (*Table, error) {
 
 // AppendRowM add construct a table row from a Row and append to the table
 func (a *Table) AppendRowM(m map[string]any) error {
-	row, err := a.convFieldsToRow(m)
+	row, err := a.convFieldsToRow(m, nil)
+	if err != nil {
+		return err
+	}
+	a.rows = append(a.rows, row)
+	return nil
+}
+
+// AppendRowS construct a table row from a struct or a map and append to the table, see Column.FieldPath
+func (a *Table) AppendRowS(v any) error {
+	row, err := a.convRecordToRow(v, nil)
 	if err != nil {
 		return err
 	}
@@ -59,49 +107,241 @@ func (a *Table) AppendRowM(m map[string]any) error {
 
 // AppendRow add construct a table row from []any and append to the table
 func (a *Table) AppendRow(c ...any) error {
-	colCount := len(a.columns)
-	if len(c) != colCount {
-		return fmt.Errorf("table has %d columns but %d is given", colCount, len(c))
+	row, err := a.convValuesToRow(c)
+	if err != nil {
+		return err
 	}
-	cells := make([](*Cell), colCount)
-	for i, col := range a.columns {
-		cells[i] = col.newCell(c[i])
+	a.rows = append(a.rows, row)
+	return nil
+}
+
+// InsertRow constructs a table row from []any and inserts it before the i-th row, the row becomes a sibling of the i-th
+// row when it is a part of a tree
+func (a *Table) InsertRow(i int, c ...any) error {
+	if i < 0 || i > len(a.rows) {
+		return fmt.Errorf("%w: row %d", ErrIndexOutOfRange, i)
+	}
+	row, err := a.convValuesToRow(c)
+	if err != nil {
+		return err
+	}
+	if i < len(a.rows) && a.rows[i].depth > 0 {
+		row.depth, row.parent, row.id = a.rows[i].depth, a.rows[i].parent, a.nextRowID()
 	}
-	a.rows = append(a.rows, cells)
+	a.rows = slices.Insert(a.rows, i, row)
+	a.refreshTreeLabel(i)
+	a.refreshTreePaths()
+	return nil
+}
+
+// UpdateRow replaces the values of the i-th row, the style of the row is kept
+func (a *Table) UpdateRow(i int, c ...any) error {
+	if i < 0 || i >= len(a.rows) {
+		return fmt.Errorf("%w: row %d", ErrIndexOutOfRange, i)
+	}
+	row, err := a.convValuesToRow(c)
+	if err != nil {
+		return err
+	}
+	a.rows[i].cells = row.cells
+	a.refreshTreeLabel(i)
+	a.refreshTreePaths()
+	return nil
+}
+
+// DeleteRow deletes the i-th row, rows of descendants are deleted as well when the row is a part of a tree
+func (a *Table) DeleteRow(i int) error {
+	if i < 0 || i >= len(a.rows) {
+		return fmt.Errorf("%w: row %d", ErrIndexOutOfRange, i)
+	}
+	// descendants follow the row, a row is deleted when its parent is deleted
+	deleted := map[int]bool{a.rows[i].id: true}
+	end := i + 1
+	for a.rows[i].id != 0 && end < len(a.rows) && deleted[a.rows[end].parent] {
+		deleted[a.rows[end].id] = true
+		end++
+	}
+	a.rows = slices.Delete(a.rows, i, end)
+	a.refreshTreePaths()
+	return nil
+}
+
+// SetCellValue replaces the value of a cell, the cell is recreated so that formatting rules of the column are applied
+func (a *Table) SetCellValue(row int, col string, v any) error {
+	if row < 0 || row >= len(a.rows) {
+		return fmt.Errorf("%w: row %d", ErrIndexOutOfRange, row)
+	}
+	cIdx, ok := a.colMap[col]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrColumnNotExist, col)
+	}
+	a.rows[row].cells[cIdx] = a.columns[cIdx].newCell(v)
+	a.refreshTreeLabel(row)
+	a.refreshTreePaths()
+	return nil
+}
+
+// InsertColumn inserts a column before the i-th column, existing rows are filled with defaultValue
+func (a *Table) InsertColumn(i int, c *Column, defaultValue any) error {
+	if i < 0 || i > len(a.columns) {
+		return fmt.Errorf("%w: column %d", ErrIndexOutOfRange, i)
+	}
+	if _, ok := a.colMap[c.Name()]; ok {
+		return fmt.Errorf("%w: %s", ErrColumnAlreadyExist, c.Name())
+	}
+	a.columns = slices.Insert(a.columns, i, c)
+	for ri := range a.rows {
+		a.rows[ri].cells = slices.Insert(a.rows[ri].cells, i, c.newCell(defaultValue))
+	}
+	a.rebuildColMap()
+	a.refreshTreePaths()
+	return nil
+}
+
+func (a *Table) RemoveColumn(name string) error {
+	cIdx, ok := a.colMap[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrColumnNotExist, name)
+	}
+	a.columns = slices.Delete(a.columns, cIdx, cIdx+1)
+	for ri := range a.rows {
+		a.rows[ri].cells = slices.Delete(a.rows[ri].cells, cIdx, cIdx+1)
+	}
+	a.rebuildColMap()
+	a.refreshTreePaths()
+	return nil
+}
+
+// MoveColumn moves a column to the given index
+func (a *Table) MoveColumn(name string, idx int) error {
+	cIdx, ok := a.colMap[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrColumnNotExist, name)
+	}
+	if idx < 0 || idx >= len(a.columns) {
+		return fmt.Errorf("%w: column %d", ErrIndexOutOfRange, idx)
+	}
+	col := a.columns[cIdx]
+	a.columns = slices.Insert(slices.Delete(a.columns, cIdx, cIdx+1), idx, col)
+	for ri := range a.rows {
+		cell := a.rows[ri].cells[cIdx]
+		a.rows[ri].cells = slices.Insert(slices.Delete(a.rows[ri].cells, cIdx, cIdx+1), idx, cell)
+	}
+	a.rebuildColMap()
+	a.refreshTreePaths()
+	return nil
+}
+
+// RenameColumn renames a column, percentages and tree labels referring to the column follow the new name. Fields of
+// rows appended later are looked up by the new name unless the column has a field path.
+func (a *Table) RenameColumn(name string, newName string) error {
+	cIdx, ok := a.colMap[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrColumnNotExist, name)
+	}
+	if _, ok := a.colMap[newName]; ok && newName != name {
+		return fmt.Errorf("%w: %s", ErrColumnAlreadyExist, newName)
+	}
+	a.columns[cIdx].name = newName
+	for _, col := range a.columns {
+		if col.percentOf == name {
+			col.percentOf = newName
+		}
+	}
+	if t := a.treePathColumnIndex(); t != -1 {
+		if tc := a.columns[t].columnCellMaker.(*TreePathColumn); tc.sty.Label == name {
+			tc.sty.Label = newName
+		}
+	}
+	a.rebuildColMap()
+	a.refreshTreePaths()
 	return nil
 }
 
 func (a *Table) AppendTrees(sty TreePathStyle, ns ...TreeNodeReader) error {
-	a.setTreePathColumn(sty)
-	ts := getTreeStatistics(ns)
-	rows := []Row{}
+	return a.AppendTreesWithOptions(sty, TreeOptions{}, ns...)
+}
+
+// TreeOptions controls which nodes of trees are shown
+type TreeOptions struct {
+	// MaxDepth hides nodes deeper than the depth, roots are at depth 1. 0 means no limit. Children of hidden nodes are
+	// not loaded unless they are counted by the elided marker, or the whole tree is walked for aggregated columns.
+	MaxDepth int
+	// Collapse hides children of nodes for which it returns true, it is called before children are loaded
+	Collapse func(n TreeNodeReader) bool
+	// ElidedMarker is the format of the marker of hidden children with the number of them, e.g. DefaultElidedMarker.
+	// No marker is shown when it is empty.
+	ElidedMarker string
+	// ElidedAsRow shows the marker as the last child row instead of after the tree path of the node
+	ElidedAsRow bool
+}
+
+// AppendTreesWithOptions appends rows of trees like AppendTrees, nodes hidden by options are not converted to rows.
+// The width of the tree path column depends on the deepest visible row.
+func (a *Table) AppendTreesWithOptions(sty TreePathStyle, opts TreeOptions, ns ...TreeNodeReader) error {
+	_, err := a.setTreePathColumn(sty)
+	if err != nil {
+		return err
+	}
+	rows := []tableRow{}
 	for _, n := range ns {
-		tmp, err := a.convTree2Rows(sty, n, ts.MaxDepth, "", false, true)
+		tmp, _, err := a.convTree2Rows(n, opts, 1, false, make([][]any, len(a.columns)))
 		if err != nil {
 			return err
 		}
 		rows = append(rows, tmp...)
 	}
 	a.rows = append(a.rows, rows...)
+	a.refreshTreePaths()
 	return nil
 }
 
 func (a *Table) Cell(col, row int) *Cell {
-	return a.rows[row][col]
+	return a.rows[row].cells[col]
 }
 
+// RowStyle sets text styles of an entire row, including cell paddings, column separators and side borders
+func (a *Table) RowStyle(row int, tss ...TextStyle) {
+	a.rows[row].style = tss
+}
+
+// Render renders the table as text drawn by the layout. Json and Yaml render a sequence of objects keyed by names of
+// visible columns instead, rows of trees are nested under the "children" field of their parents. Html renders an HTML
+// table, tables with trees are rendered as treegrids.
 func (a *Table) Render(o Output) (string, error) {
+	return a.RenderWithLayout(o, nil)
+}
+
+// RenderWithLayout renders the table like Render with another layout, the layout of the table is used when l is nil.
+// Neither the table nor its columns are changed by rendering, so that a table can be rendered concurrently.
+func (a *Table) RenderWithLayout(o Output, l *TableLayout) (string, error) {
+	if l == nil {
+		l = &a.Layout
+	}
 	out := ""
 	err := func() error {
-		err := a.enforceWidth(o)
+		switch o {
+		case Json, Yaml:
+			s, err := a.renderData(o)
+			out = s
+			return err
+		case Html:
+			out = a.renderHtml()
+			return nil
+		}
+		err := l.Validate()
+		if err != nil {
+			return err
+		}
+		p, err := a.planRender(o, *l)
 		if err != nil {
 			return err
 		}
-		header, err := a.renderHeader(o)
+		header, err := a.renderHeader(p)
 		if err != nil {
 			return err
 		}
-		body, err := a.renderBody(o)
+		body, err := a.renderBody(p)
 		if err != nil {
 			return err
 		}
@@ -114,9 +354,72 @@ func (a *Table) Render(o Output) (string, error) {
 	return out, nil
 }
 
+// Stats returns widths of columns, the height of the header and heights of rows of the table rendered by Render(o),
+// e.g. for ImportOptions.RowHeights
+func (a *Table) Stats(o Output) (TableStats, error) {
+	if err := a.Layout.Validate(); err != nil {
+		return TableStats{}, err
+	}
+	p, err := a.planRender(o, a.Layout)
+	if err != nil {
+		return TableStats{}, err
+	}
+	return p.stats, nil
+}
+
+// Columns returns all columns of the table including hidden ones
+func (a *Table) Columns() []*Column {
+	return slices.Clone(a.columns)
+}
+
+func (a *Table) RowCount() int {
+	return len(a.rows)
+}
+
+// Row returns the values of a row in the order of columns
+func (a *Table) Row(i int) []any {
+	out := make([]any, len(a.columns))
+	for ci, c := range a.rows[i].cells {
+		out[ci] = c.Data()
+	}
+	return out
+}
+
+// RowStrings returns the text of a row in the order of columns
+func (a *Table) RowStrings(i int) []string {
+	out := make([]string, len(a.columns))
+	for ci, c := range a.rows[i].cells {
+		out[ci] = c.String()
+	}
+	return out
+}
+
+// RowMap returns the values of a row keyed by column names
+func (a *Table) RowMap(i int) map[string]any {
+	return a.rowMap(a.rows[i])
+}
+
+func (a *Table) rowMap(row tableRow) map[string]any {
+	out := make(map[string]any, len(a.columns))
+	for ci, col := range a.columns {
+		out[col.name] = row.cells[ci].Data()
+	}
+	return out
+}
+
+// All returns an iterator over the index and the values of each row
+func (a *Table) All() iter.Seq2[int, []any] {
+	return func(yield func(int, []any) bool) {
+		for i := range a.rows {
+			if !yield(i, a.Row(i)) {
+				return
+			}
+		}
+	}
+}
+
 func (a *Table) ResetData() {
-	a.rows = []Row{}
-	a.stats = TableStats{}
+	a.rows = []tableRow{}
 }
 
 func (a *Table) GetColumn(name string) (*Column, error) {
@@ -137,29 +440,57 @@ func (a *Table) appendColumn(c *Column) (*Table, error) {
 	return a, nil
 }
 
-func (a *Table) setTreePathColumn(sty TreePathStyle) *Column {
+func (a *Table) rebuildColMap() {
+	a.colMap = make(map[string]int, len(a.columns))
+	for i, col := range a.columns {
+		a.colMap[col.name] = i
+	}
+}
+
+// treePathColumnIndex returns the index of the tree path column, -1 is returned when there is no tree path column
+func (a *Table) treePathColumnIndex() int {
+	return slices.IndexFunc(a.columns, func(col *Column) bool {
+		_, ok := col.columnCellMaker.(*TreePathColumn)
+		return ok
+	})
+}
+
+// treePathStyle returns the style of the tree path column, an empty style is returned when there is no tree path column
+func (a *Table) treePathStyle() TreePathStyle {
+	cIdx := a.treePathColumnIndex()
+	if cIdx == -1 {
+		return TreePathStyle{}
+	}
+	return a.columns[cIdx].columnCellMaker.(*TreePathColumn).sty
+}
+
+// setTreePathColumn replaces the tree path column or prepends one, rows that already exist get an empty path
+func (a *Table) setTreePathColumn(sty TreePathStyle) (*Column, error) {
 	col := NewTreePathColumn(sty)
-	if len(a.columns) == 0 {
-		a.columns = append(a.columns, col)
-	} else {
-		switch a.columns[0].columnCellMaker.(type) {
-		case *TreePathColumn:
-			a.columns[0] = col
-		default:
-			a.columns = append([]*Column{col}, a.columns...)
+	if cIdx := a.treePathColumnIndex(); cIdx != -1 {
+		if i, ok := a.colMap[col.name]; ok && i != cIdx {
+			return nil, fmt.Errorf("%w: %s", ErrColumnAlreadyExist, col.name)
 		}
+		a.columns[cIdx] = col
+		a.rebuildColMap()
+		return col, nil
+	}
+	err := a.InsertColumn(0, col, "")
+	if err != nil {
+		return nil, err
 	}
-	return col
+	return col, nil
 }
 
-func (a *Table) updateStatistics(o Output) error {
+// updateStatistics measures columns and rows with width limits of the plan
+func (a *Table) updateStatistics(p *renderPlan) error {
 	stats := TableStats{
 		ColumnWidths: make([]int, len(a.columns)),
 		RowHeights:   make([]int, len(a.rows)),
 		HeaderHeight: 0,
 	}
 	for i, col := range a.columns {
-		w, h, err := col.newHeader().stats(a.columns[i].widthLimit, o)
+		w, h, err := col.newHeader(a.headerTranslator).stats(p.widthLimits[i], p.o)
 		if err != nil {
 			return err
 		}
@@ -171,8 +502,8 @@ func (a *Table) updateStatistics(o Output) error {
 		}
 	}
 	for ri, row := range a.rows {
-		for ci, cell := range row {
-			w, h, err := cell.stats(a.columns[ci].widthLimit, o)
+		for ci, cell := range row.cells {
+			w, h, err := cell.stats(p.widthLimits[ci], p.o)
 			if err != nil {
 				return err
 			}
@@ -184,21 +515,24 @@ func (a *Table) updateStatistics(o Output) error {
 			}
 		}
 	}
-	a.stats = stats
+	p.stats = stats
 	return nil
 }
 
-// enforceWidth adjusts widthlimit for each dynamic columns to fit the table width
-func (a *Table) enforceWidth(o Output) error {
+// planRender plans the rendering, width limits of dynamic columns are adjusted on the plan to fit the table width
+func (a *Table) planRender(o Output, l TableLayout) (*renderPlan, error) {
+	p := &renderPlan{o: o, layout: l, widthLimits: make([]int, len(a.columns))}
+	for i, col := range a.columns {
+		p.widthLimits[i] = col.widthLimit
+	}
 	// update statistics to get original column width and row height
-	err := a.updateStatistics(o)
+	err := a.updateStatistics(p)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	l := a.Layout
 	if l.Width == 0 {
-		return nil
+		return p, nil
 	}
 	originalWidth := 0
 	// left and right separactors
@@ -211,15 +545,15 @@ func (a *Table) enforceWidth(o Output) error {
 			continue
 		}
 		colCount++
-		originalWidth += a.stats.ColumnWidths[i]
+		originalWidth += p.stats.ColumnWidths[i]
 	}
 	// column separactors
-	if a.Layout.ShowColumnSeparator {
+	if p.layout.ShowColumnSeparator {
 		originalWidth += colCount - 1
 	}
 	// do nothing when table width equals to the expected size
-	if originalWidth == a.Layout.Width {
-		return nil
+	if originalWidth == p.layout.Width {
+		return p, nil
 	}
 
 	// skip columns which meet conditions below
@@ -234,113 +568,160 @@ func (a *Table) enforceWidth(o Output) error {
 		if !col.autoWidthControl {
 			continue
 		}
-		if a.stats.ColumnWidths[i] <= AdjustableColumnMinWidth {
+		if p.stats.ColumnWidths[i] <= AdjustableColumnMinWidth {
 			continue
 		}
 		colIndexes = append(colIndexes, i)
 	}
 	if len(colIndexes) == 0 {
-		return fmt.Errorf("%w: %w", ErrEnforcingTableWidth, ErrNoAdjustableColumn)
+		return nil, fmt.Errorf("%w: %w", ErrEnforcingTableWidth, ErrNoAdjustableColumn)
 	}
 
-	if originalWidth < a.Layout.Width {
+	if originalWidth < p.layout.Width {
 		// increase column width
-		widthToAdd := a.Layout.Width - originalWidth
+		widthToAdd := p.layout.Width - originalWidth
 		widthToAddPerCol := widthToAdd / len(colIndexes)
 		widthToAddRemain := widthToAdd % len(colIndexes)
 		for i, ci := range colIndexes {
-			w := a.stats.ColumnWidths[ci] + widthToAddPerCol
+			w := p.stats.ColumnWidths[ci] + widthToAddPerCol
 			if i < widthToAddRemain {
 				w += 1
 			}
-			a.columns[ci].Width(w, false)
+			p.widthLimits[ci] = w
 		}
 	} else {
 		// reduce column width for wordwrap enabled columns
 		tmp := originalWidth
-		for i, colIdx := range colIndexes {
-			if !a.columns[colIdx].autoWidthControl {
-				colIndexes = append(colIndexes[:i], colIndexes[i+1:]...)
-				continue
-			}
-			tmp -= a.stats.ColumnWidths[colIdx]
+		for _, colIdx := range colIndexes {
+			tmp -= p.stats.ColumnWidths[colIdx]
 		}
-		if tmp+(AdjustableColumnMinWidth*len(colIndexes)) > a.Layout.Width {
-			return fmt.Errorf("enforcing table width to %d is not possible since rows are too long", a.Layout.Width)
+		mins := map[int]int{}
+		sumMins := 0
+		for _, colIdx := range colIndexes {
+			mins[colIdx] = max(AdjustableColumnMinWidth, a.columnMinWidth(colIdx, o))
+			sumMins += mins[colIdx]
 		}
-		widthPerCol := (a.Layout.Width - tmp) / len(colIndexes)
-		widthLeft := (a.Layout.Width - tmp) % len(colIndexes)
-		for i, colIdx := range colIndexes {
-			w := widthPerCol
-			if i < widthLeft {
-				w += 1
+		if tmp+sumMins > p.layout.Width {
+			return nil, fmt.Errorf("enforcing table width to %d is not possible since rows are too long", p.layout.Width)
+		}
+		// columns which need more than an even share keep their minimum width
+		remain := p.layout.Width - tmp
+		for changed := true; changed; {
+			changed = false
+			for i, colIdx := range colIndexes {
+				if mins[colIdx] > remain/len(colIndexes) {
+					p.widthLimits[colIdx] = mins[colIdx]
+					remain -= mins[colIdx]
+					colIndexes = slices.Delete(colIndexes, i, i+1)
+					changed = len(colIndexes) > 0
+					break
+				}
+			}
+		}
+		if len(colIndexes) > 0 {
+			widthPerCol := remain / len(colIndexes)
+			widthLeft := remain % len(colIndexes)
+			for i, colIdx := range colIndexes {
+				w := widthPerCol
+				if i < widthLeft {
+					w += 1
+				}
+				p.widthLimits[colIdx] = w
 			}
-			a.columns[colIdx].Width(w, false)
 		}
 	}
 
 	// table statistics has to be updated since width limit has changed
-	err = a.updateStatistics(o)
+	err = a.updateStatistics(p)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return p, nil
 }
 
-func (a *Table) renderHeader(o Output) (string, error) {
-	out := a.renderHorizontal("HeaderTop")
-	row := make(Row, len(a.columns))
+// columnMinWidth returns the width a column needs at least, tree paths can not be wrapped
+func (a *Table) columnMinWidth(ci int, o Output) int {
+	out := 0
+	for _, row := range a.rows {
+		if tc, ok := row.cells[ci].cellRenderer.(*TreePathCell); ok {
+			out = max(out, tc.minWidth(row.cells[ci], o))
+		}
+	}
+	return out
+}
+
+func (a *Table) renderHeader(p *renderPlan) (string, error) {
+	out := a.renderHorizontal(p, "HeaderTop")
+	row := tableRow{cells: make(Row, len(a.columns))}
 	for i, col := range a.columns {
-		row[i] = col.newHeader()
+		row.cells[i] = col.newHeader(a.headerTranslator)
 	}
-	if a.Layout.ShowHeader {
-		tmp, err := a.renderRow(row, a.stats.HeaderHeight, o)
+	if p.layout.ShowHeader {
+		tmp, err := a.renderRow(p, row, p.stats.HeaderHeight, p.layout.HeaderLeft, p.layout.HeaderRight, p.layout.HeaderSeparator, nil)
 		if err != nil {
 			return "", err
 		}
 		out += tmp
 	}
-	out += a.renderHorizontal("HeaderBottom")
+	out += a.renderHorizontal(p, "HeaderBottom")
 	return out, nil
 }
 
-func (a *Table) renderBody(o Output) (string, error) {
-	out := a.renderHorizontal("BodyTop")
+func (a *Table) renderBody(p *renderPlan) (string, error) {
+	out := a.renderHorizontal(p, "BodyTop")
 	for i := 0; i < len(a.rows); i++ {
-		tmp, err := a.renderRow(a.rows[i], a.stats.RowHeights[i], o)
+		tmp, err := a.renderRow(p, a.rows[i], p.stats.RowHeights[i], p.layout.RowLeft, p.layout.RowRight, p.layout.ColumnSeparator, a.rowStyle(p, i))
 		if err != nil {
 			return "", err
 		}
 		out += tmp
 		if i != len(a.rows)-1 {
-			out += a.renderHorizontal("Row")
+			out += a.renderHorizontal(p, "Row")
 		}
 	}
-	out += a.renderHorizontal("BodyBottom")
+	out += a.renderHorizontal(p, "BodyBottom")
 	return out, nil
 }
 
-func (a *Table) renderRow(cells Row, h int, o Output) (string, error) {
+// rowStyle returns text styles of a body row, the depth style of tree rows is applied on top of the stripe style and
+// styles of the row are applied on top of both
+func (a *Table) rowStyle(p *renderPlan, i int) []TextStyle {
+	sty := slices.Concat(a.treePathStyle().depthStyle(a.rows[i].depth), a.rows[i].style)
+	if i%2 == 1 {
+		return slices.Concat(p.layout.StripeStyle, sty)
+	}
+	return sty
+}
+
+// renderRow renders the cells of a row with the given vertical borders, separator and row styles
+func (a *Table) renderRow(p *renderPlan, row tableRow, h int, left rune, right rune, separator rune, sty []TextStyle) (string, error) {
 	out := ""
 	colAndRows := make([][]string, 0)
 	for i, col := range a.columns {
 		if col.hidden {
 			continue
 		}
-		tmp, err := cells[i].render(a.stats.ColumnWidths[i], h, o)
+		cell := row.cells[i]
+		if len(sty) > 0 {
+			// render a copy of the cell so that the row style is merged without touching the cell
+			tmp := *cell
+			tmp.style = slices.Concat(sty, cell.style)
+			cell = &tmp
+		}
+		tmp, err := cell.render(p.stats.ColumnWidths[i], h, p.o)
 		if err != nil {
 			return "", err
 		}
 		colAndRows = append(colAndRows, tmp)
 	}
-	colSep := string(a.Layout.ColumnSeparator)
-	if !a.Layout.ShowColumnSeparator {
+	colSep := formatText(string(separator), p.o, sty...)
+	if !p.layout.ShowColumnSeparator {
 		colSep = ""
 	}
 
 	strLeft, strRight := "", ""
-	if a.Layout.ShowSideBorder {
-		strLeft, strRight = string(a.Layout.RowLeft), string(a.Layout.RowRight)
+	if p.layout.ShowSideBorder {
+		strLeft, strRight = formatText(string(left), p.o, sty...), formatText(string(right), p.o, sty...)
 	}
 	for r := 0; r < h; r++ {
 		lineItems := []string{}
@@ -353,25 +734,26 @@ func (a *Table) renderRow(cells Row, h int, o Output) (string, error) {
 	return out, nil
 }
 
-func (a *Table) renderHorizontal(t string) string {
-	l := a.Layout
+func (a *Table) renderHorizontal(p *renderPlan, t string) string {
+	l := p.layout
 	switch t {
 	case "HeaderTop":
-		return a._renderHorizontal(l.ShowHeaderTopBorder, l.HeaderTopLeft, l.HeaderTopRight, l.HeaderTopSeparator, l.HeaderTopHorizontal)
+		return a._renderHorizontal(p, l.ShowHeaderTopBorder, l.HeaderTopLeft, l.HeaderTopRight, l.HeaderTopSeparator, l.HeaderTopHorizontal)
 	case "HeaderBottom":
-		return a._renderHorizontal(l.ShowHeaderBottemBorder, l.HeaderBottomLeft, l.HeaderBottomRight, l.HeaderBottomSeparator, l.HeaderBottomHorizontal)
+		return a._renderHorizontal(p, l.ShowHeaderBottemBorder, l.HeaderBottomLeft, l.HeaderBottomRight, l.HeaderBottomSeparator, l.HeaderBottomHorizontal)
 	case "BodyTop":
-		return a._renderHorizontal(l.ShowBodyTopBorder, l.BodyTopLeft, l.BodyTopRight, l.BodyTopSeparator, l.BodyTopHorizontal)
+		return a._renderHorizontal(p, l.ShowBodyTopBorder, l.BodyTopLeft, l.BodyTopRight, l.BodyTopSeparator, l.BodyTopHorizontal)
 	case "BodyBottom":
-		return a._renderHorizontal(l.ShowBodyBottomBorder, l.BodyBottomLeft, l.BodyBottomRight, l.BodyBottomSeparator, l.BodyBottomHorizontal)
+		return a._renderHorizontal(p, l.ShowBodyBottomBorder, l.BodyBottomLeft, l.BodyBottomRight, l.BodyBottomSeparator, l.BodyBottomHorizontal)
 	case "Row":
-		return a._renderHorizontal(l.ShowRowSeparator, l.RowLeft, l.RowRight, l.RowSeparator, l.RowHorizontal)
+		left, right := l.rowSeparatorSides()
+		return a._renderHorizontal(p, l.ShowRowSeparator, left, right, l.RowSeparator, l.RowHorizontal)
 	default:
 		return ""
 	}
 }
 
-func (a *Table) _renderHorizontal(show bool, left rune, right rune, separator rune, horizontal rune) string {
+func (a *Table) _renderHorizontal(p *renderPlan, show bool, left rune, right rune, separator rune, horizontal rune) string {
 	if !show {
 		return ""
 	}
@@ -381,73 +763,402 @@ func (a *Table) _renderHorizontal(show bool, left rune, right rune, separator ru
 		if col.hidden {
 			continue
 		}
-		wCol := a.stats.ColumnWidths[i]
+		wCol := p.stats.ColumnWidths[i]
 		tmp = append(tmp, strings.Repeat(string(horizontal), wCol))
 	}
 	strColSep, strLeft, strRight := "", "", ""
-	if a.Layout.ShowColumnSeparator {
+	if p.layout.ShowColumnSeparator {
 		strColSep = string(separator)
 	}
-	if a.Layout.ShowSideBorder {
+	if p.layout.ShowSideBorder {
 		strLeft, strRight = string(left), string(right)
 	}
 	out = fmt.Sprintf("%s%s%s\n", strLeft, strings.Join(tmp, strColSep), strRight)
 	return out
 }
 
-func (a *Table) convTree2Rows(sty TreePathStyle, node TreeNodeReader, maxDeepth int, prefix string, islast bool, isroot bool) ([]Row, error) {
-	// generate data fields of the row
-	fields := node.Fields()
-	fields[sty.Name] = ""
-	row, err := a.convFieldsToRow(fields)
+// convTree2Rows converts a node and its visible descendants into rows. Aggregates are computed bottom-up in the same
+// walk, values of nodes of aggregated columns are appended to values in the order of the walk so that values of a node
+// and its descendants are the range appended during its walk. Rows of hidden nodes are not generated.
+func (a *Table) convTree2Rows(node TreeNodeReader, opts TreeOptions, depth int, hidden bool, values [][]any) ([]tableRow, [][]any, error) {
+	// children are loaded only when they are shown, counted or aggregated, so that lazy trees are not walked
+	// beyond the depth limit
+	aggregate := a.hasAggregate()
+	folded := opts.MaxDepth > 0 && depth >= opts.MaxDepth || !hidden && opts.Collapse != nil && opts.Collapse(node)
+	var children []TreeNodeReader
+	if !hidden && (!folded || opts.ElidedMarker != "") || aggregate {
+		children = node.Children()
+	}
+
+	// fields of the node are collected once for aggregates, the row and the label
+	rec := treeRecord(node)
+	start := make([]int, len(values))
+	for i := range values {
+		start[i] = len(values[i])
+	}
+	if aggregate {
+		for i, col := range a.columns {
+			if v, ok := lookupField(rec, col); ok && col.reducer != nil {
+				values[i] = append(values[i], v)
+			}
+		}
+	}
+
+	// walk children first so that aggregates are known, children of folded nodes are walked for aggregates only
+	childRows := [][]tableRow{}
+	for _, cld := range children {
+		if (hidden || folded) && !aggregate {
+			break
+		}
+		rows, tmp, err := a.convTree2Rows(cld, opts, depth+1, hidden || folded, values)
+		if err != nil {
+			return nil, nil, err
+		}
+		values = tmp
+		if !hidden && !folded {
+			childRows = append(childRows, rows)
+		}
+	}
+	if hidden {
+		return nil, values, nil
+	}
+
+	// generate data fields of the row, the tree path is generated by refreshTreePaths
+	computed := map[int]any{}
+	for i, col := range a.columns {
+		if col.percentOf != "" {
+			computed[i] = ""
+		} else if col.reducer != nil && len(values[i]) > start[i] {
+			if v := col.reducer(values[i][start[i]:]); v != nil {
+				computed[i] = v
+			}
+		}
+	}
+	row, err := a.convTreeNodeToRow(rec, computed)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	// percentages of the node are computed as a root and replaced by the parent
+	a.fillPercents(&row, nil)
+	row.depth, row.id = depth, a.nextRowID()
+	// labels of columns are looked up like their cells so that field paths of the columns apply
+	if sty := a.treePathStyle(); sty.Label != "" {
+		v, ok := lookupKey(rec, sty.Label)
+		if cIdx, isCol := a.colMap[sty.Label]; isCol {
+			v, ok = lookupField(rec, a.columns[cIdx])
+		}
+		if ok {
+			row.label = fmt.Sprintf("%v", v)
+		}
+	}
+	out := []tableRow{row}
 
-	// generate tree path
-	path := ""
-	if isroot {
-		path = sty.Root
-		prefix += sty.PrefixBlank
-	} else {
-		path += prefix
-		if islast {
-			path += sty.Terminal
-			prefix += sty.PrefixBlank
+	if len(children) == 0 {
+		return out, values, nil
+	}
+	if folded {
+		if opts.ElidedMarker == "" {
+			return out, values, nil
+		}
+		marker := fmt.Sprintf(opts.ElidedMarker, len(children))
+		if !opts.ElidedAsRow {
+			out[0].elided = marker
+			return out, values, nil
+		}
+		cells := make([]*Cell, len(a.columns))
+		for i, col := range a.columns {
+			cells[i] = col.newCell("")
+		}
+		return append(out, tableRow{cells: cells, depth: depth + 1, id: a.nextRowID(), parent: row.id, elided: marker, marker: true}), values, nil
+	}
+	for _, rows := range childRows {
+		rows[0].parent = row.id
+		a.fillPercents(&rows[0], &row)
+		out = append(out, rows...)
+	}
+	return out, values, nil
+}
+
+// fillPercents sets values of columns of percentages of a row of a tree node from the row of its parent
+func (a *Table) fillPercents(row *tableRow, parent *tableRow) {
+	for i, col := range a.columns {
+		if j, ok := a.colMap[col.percentOf]; ok && col.percentOf != "" {
+			var pv any
+			if parent != nil {
+				pv = parent.cells[j].Data()
+			}
+			row.cells[i] = col.newCell(percentOfParent(row.cells[j].Data(), pv, parent == nil))
+		}
+	}
+}
+
+// hasAggregate returns true when values of a column are aggregated from descendants of tree nodes
+func (a *Table) hasAggregate() bool {
+	return slices.ContainsFunc(a.columns, func(col *Column) bool {
+		return col.reducer != nil
+	})
+}
+
+// refreshTreeLabel updates the label of a tree row from its cells when the label field is a column
+func (a *Table) refreshTreeLabel(i int) {
+	row := &a.rows[i]
+	if row.depth == 0 || row.marker {
+		return
+	}
+	if cIdx, ok := a.colMap[a.treePathStyle().Label]; ok && a.treePathStyle().Label != "" {
+		row.label = fmt.Sprintf("%v", row.cells[cIdx].Data())
+	}
+}
+
+// nextRowID returns a new id for a row of a tree
+func (a *Table) nextRowID() int {
+	a.lastRowID++
+	return a.lastRowID
+}
+
+// refreshTreeDepths sets depths of tree rows from their parents, rows whose parent is not in the table are roots.
+// Parents are always before their children.
+func (a *Table) refreshTreeDepths() {
+	depths := map[int]int{}
+	for i := range a.rows {
+		row := &a.rows[i]
+		if row.id == 0 {
+			row.depth = 0
+			continue
+		}
+		if d, ok := depths[row.parent]; ok && row.parent != 0 {
+			row.depth = d + 1
 		} else {
-			path += sty.Middle
-			prefix += sty.PrefixLeveled
+			row.depth, row.parent = 1, 0
 		}
+		depths[row.id] = row.depth
 	}
-	if len(node.Children()) > 0 {
-		path += sty.Children
+}
+
+// refreshTreePaths regenerates tree paths of all tree rows from the sequence of their depths, depths are set from
+// parents first. A node is the last child
+// when no row at the same depth follows before a row of a lower depth, and it has children when the next row is deeper.
+func (a *Table) refreshTreePaths() {
+	a.refreshTreeDepths()
+	cIdx := a.treePathColumnIndex()
+	if cIdx == -1 {
+		return
 	}
-	pathWidth := 1 + (maxDeepth * 2)
-	pad := strings.Repeat(sty.PadLine, pathWidth-utf8.RuneCountInString(path))
-	path += pad
-	row[0].Value(path)
+	col := a.columns[cIdx]
+	sty := col.columnCellMaker.(*TreePathColumn).sty
 
-	out := []Row{row}
-	// generate rows for children nodes
-	for i, cld := range node.Children() {
-		tmp, err := a.convTree2Rows(sty, cld, maxDeepth, prefix, i == len(node.Children())-1, false)
-		if err != nil {
-			return nil, err
+	maxDepth := 0
+	for _, row := range a.rows {
+		maxDepth = max(maxDepth, row.depth)
+	}
+	isLast := make([]bool, len(a.rows))
+	seen := make([]bool, maxDepth+2)
+	for i := len(a.rows) - 1; i >= 0; i-- {
+		d := a.rows[i].depth
+		if d == 0 {
+			clear(seen)
+			continue
 		}
-		out = append(out, tmp...)
+		isLast[i] = !seen[d]
+		seen[d] = true
+		clear(seen[d+1:])
 	}
-	return out, nil
+
+	pathWidth := 1 + (maxDepth * sty.indent())
+	lastAt := make([]bool, maxDepth+2)
+	for i := range a.rows {
+		row := &a.rows[i]
+		if row.depth == 0 {
+			continue
+		}
+		lastAt[row.depth] = isLast[i]
+		// ext continues the path on following lines of the row
+		rootPrefix := sty.segment(sty.PrefixBlank, sty.PadBlank)
+		if sty.LabelInPath && sty.Root == "" {
+			rootPrefix = ""
+		}
+		path, ext := "", ""
+		if row.depth == 1 {
+			path, ext = sty.segment(sty.Root, sty.PadLine), rootPrefix
+			if sty.LabelInPath {
+				path = sty.Root
+			}
+		} else {
+			prefix := rootPrefix
+			for d := 2; d < row.depth; d++ {
+				if lastAt[d] {
+					prefix += sty.segment(sty.PrefixBlank, sty.PadBlank)
+				} else {
+					prefix += sty.segment(sty.PrefixLeveled, sty.PadBlank)
+				}
+			}
+			if isLast[i] {
+				path, ext = prefix+sty.segment(sty.Terminal, sty.PadLine), prefix+sty.segment(sty.PrefixBlank, sty.PadBlank)
+			} else {
+				path, ext = prefix+sty.segment(sty.Middle, sty.PadLine), prefix+sty.segment(sty.PrefixLeveled, sty.PadBlank)
+			}
+		}
+		if sty.LabelInPath {
+			// the label starts right after the connector, so that the path is neither padded nor continued to children
+			if sty.Children != "" && i+1 < len(a.rows) && a.rows[i+1].depth == row.depth+1 {
+				path += sty.segment(sty.Children, sty.PadLine)
+			}
+			ext += strings.Repeat(sty.PadBlank, max(utf8.RuneCountInString(path)-utf8.RuneCountInString(ext), 0))
+		} else {
+			if i+1 < len(a.rows) && a.rows[i+1].depth == row.depth+1 {
+				path += sty.segment(sty.Children, sty.PadLine)
+				ext += sty.segment(sty.PrefixLeveled, sty.PadBlank)
+			}
+			path += strings.Repeat(sty.PadLine, max(pathWidth-utf8.RuneCountInString(path), 0))
+			ext += strings.Repeat(sty.PadBlank, max(pathWidth-utf8.RuneCountInString(ext), 0))
+		}
+		row.cells[cIdx] = col.newCell(path)
+		tc := row.cells[cIdx].cellRenderer.(*TreePathCell)
+		tc.extension, tc.label, tc.elided = ext, row.label, row.elided
+	}
+}
+
+func (a *Table) convValuesToRow(c []any) (tableRow, error) {
+	colCount := len(a.columns)
+	if len(c) != colCount {
+		return tableRow{}, fmt.Errorf("table has %d columns but %d is given", colCount, len(c))
+	}
+	cells := make([](*Cell), colCount)
+	for i, col := range a.columns {
+		cells[i] = col.newCell(c[i])
+	}
+	return tableRow{cells: cells}, nil
+}
+
+// convFieldsToRow looks up values of the row by column names, the value of the tree path column is generated by the table.
+// Values of columns in computed are used instead of fields.
+func (a *Table) convFieldsToRow(fields map[string]any, computed map[int]any) (tableRow, error) {
+	if err := a.checkFieldKeys(maps.Keys(fields)); err != nil {
+		return tableRow{}, err
+	}
+	return a.convRecordToRow(fields, computed)
+}
+
+// checkFieldKeys returns ErrUnknownField in the strict mode when a key is not used by any column
+func (a *Table) checkFieldKeys(keys iter.Seq[string]) error {
+	if a.strictFields {
+		known := map[string]bool{}
+		for _, col := range a.columns {
+			if len(col.fieldPath) > 0 {
+				known[col.fieldPath[0].key] = true
+			} else {
+				known[col.name] = true
+			}
+		}
+		if sty := a.treePathStyle(); sty.Label != "" {
+			known[sty.Label] = true
+		}
+		unknown := []string{}
+		for k := range keys {
+			if !known[k] {
+				unknown = append(unknown, k)
+			}
+		}
+		if len(unknown) > 0 {
+			slices.Sort(unknown)
+			return fmt.Errorf("%w: %s", ErrUnknownField, strings.Join(unknown, ", "))
+		}
+	}
+	return nil
+}
+
+// convTreeNodeToRow converts the record of a tree node returned by treeRecord like convFieldsToRow, fields of nodes
+// which look up values on demand are not collected into a map
+func (a *Table) convTreeNodeToRow(rec any, computed map[int]any) (tableRow, error) {
+	l, ok := rec.(fieldLookup)
+	if !ok {
+		return a.convFieldsToRow(rec.(map[string]any), computed)
+	}
+	if err := a.checkFieldKeys(l.fieldKeys()); err != nil {
+		return tableRow{}, err
+	}
+	return a.convRecordToRow(l, computed)
 }
 
-func (a *Table) convFieldsToRow(fields map[string]any) (Row, error) {
-	out := make(Row, len(a.columns))
+// convRecordToRow looks up values of the row from a map or a struct by field paths or names of columns,
+// values of columns in computed are used instead of fields
+func (a *Table) convRecordToRow(rec any, computed map[int]any) (tableRow, error) {
+	out := tableRow{cells: make(Row, len(a.columns))}
 	for i, col := range a.columns {
-		v, ok := fields[col.name]
+		if _, ok := col.columnCellMaker.(*TreePathColumn); ok {
+			out.cells[i] = col.newCell("")
+			continue
+		}
+		if v, ok := computed[i]; ok {
+			out.cells[i] = col.newCell(v)
+			continue
+		}
+		if col.fieldPathErr != nil {
+			return tableRow{}, col.fieldPathErr
+		}
+		v, ok := lookupField(rec, col)
 		if !ok {
-			return nil, fmt.Errorf("%w: %s", ErrFieldIsMissing, col.name)
+			tmp, err := a.missingFieldValue(col)
+			if err != nil {
+				return tableRow{}, err
+			}
+			v = tmp
 		}
 		c := col.newCell(v)
-		out[i] = c
+		out.cells[i] = c
 	}
 	return out, nil
 }
+
+// missingFieldValue returns the value for a column whose field is missing
+func (a *Table) missingFieldValue(col *Column) (any, error) {
+	if col.hasDefault {
+		return col.defaultValue, nil
+	}
+	if a.allowMissing {
+		return a.placeholder, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrFieldIsMissing, col.name)
+}
+
+// fieldLookup is implemented by records which compute values of fields on demand instead of holding them in a map
+type fieldLookup interface {
+	lookupKey(key string) (any, bool)
+	fieldKeys() iter.Seq[string]
+}
+
+func lookupField(rec any, col *Column) (any, bool) {
+	if len(col.fieldPath) > 0 {
+		if l, ok := rec.(fieldLookup); ok {
+			if col.fieldPath[0].isIdx {
+				return nil, false
+			}
+			v, ok := l.lookupKey(col.fieldPath[0].key)
+			if !ok {
+				return nil, false
+			}
+			return lookupFieldPath(v, col.fieldPath[1:])
+		}
+		return lookupFieldPath(rec, col.fieldPath)
+	}
+	return lookupKey(rec, col.name)
+}
+
+func lookupKey(rec any, key string) (any, bool) {
+	switch r := rec.(type) {
+	case map[string]any:
+		v, ok := r[key]
+		return v, ok
+	case fieldLookup:
+		return r.lookupKey(key)
+	}
+	return lookupFieldPath(rec, []pathSegment{{key: key}})
+}
+
+// treeRecord returns the record which values of a tree node are looked up from
+func treeRecord(n TreeNodeReader) any {
+	if l, ok := n.(fieldLookup); ok {
+		return l
+	}
+	return n.Fields()
+}
@@ -1,7 +1,9 @@
 package gotable
 
 import (
+	"errors"
 	"strings"
+	"sync"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -153,6 +155,230 @@ var _ = Describe("Table Test Suites", func() {
 			_, err := tb.Render(Console)
 			Expect(err).ShouldNot(BeNil())
 		})
+		It("t6", func() {
+			tb := NewTable(DefaultTableLayout().StripeRows(BgBlue))
+			tb.AppendColumn(test_NewStdColumn("ID"))
+			tb.AppendColumn(test_NewStdColumn("Data").BodyStyle(DefauleBodyStyle().Text(Red)))
+			tb.AppendRow(1, strShort1)
+			tb.AppendRow(2, strShort2)
+			tb.AppendRow(3, strShort1)
+			tb.RowStyle(2, Bold)
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				"+----+-------------+",
+				"| ID |    Data     |",
+				"+----+-------------+",
+				"| 1  |\x1b[31m abcd        \x1b[39m|",
+				"\x1b[44m|\x1b[49m\x1b[44m 2  \x1b[49m\x1b[44m|\x1b[49m\x1b[31;44m ab cd ef gh \x1b[39;49m\x1b[44m|\x1b[49m",
+				"\x1b[1m|\x1b[22m\x1b[1m 3  \x1b[22m\x1b[1m|\x1b[22m\x1b[1;31m abcd        \x1b[22;39m\x1b[1m|\x1b[22m",
+				"+----+-------------+",
+				"",
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+		})
+	})
+
+	Context("render-header", func() {
+		It("t1", func() {
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("cpu_pct").Title("CPU").Subtitle("(%)"))
+			tb.AppendColumn(test_NewStdColumn("mem").Title("Memory\nUsage"))
+			Expect(tb.AppendRowM(map[string]any{"cpu_pct": 12.5, "mem": "1G"})).Should(BeNil())
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				"+------+--------+",
+				"| CPU  | Memory |",
+				"| (%)  | Usage  |",
+				"+------+--------+",
+				"| 12.5 | 1G     |",
+				"+------+--------+",
+				"",
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+		})
+		It("t2", func() {
+			dict := map[string]string{"CPU": "处理器", "(%)": "（百分比）"}
+			tb := NewTable(nil).HeaderTranslator(func(s string) string { return dict[s] })
+			tb.AppendColumn(test_NewStdColumn("cpu_pct").Title("CPU").Subtitle("(%)"))
+			tb.AppendColumn(test_NewStdColumn("ID"))
+			tb.AppendRow(12.5, 1)
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				"+------------+----+",
+				"|   处理器   | ID |",
+				"| （百分比） |    |",
+				"+------------+----+",
+				"| 12.5       | 1  |",
+				"+------------+----+",
+				"",
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+		})
+	})
+
+	Context("fields", func() {
+		It("t1", func() {
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data"))
+			err := tb.AppendRowM(map[string]any{"ID": 1})
+			Expect(errors.Is(err, ErrFieldIsMissing)).Should(BeTrue())
+			Expect(tb.AppendRowM(map[string]any{"ID": 1, "Data": strShort1, "Other": 1})).Should(BeNil())
+			tb.StrictFields(true)
+			err = tb.AppendRowM(map[string]any{"ID": 1, "Data": strShort1, "Other": 1, "Another": 2})
+			Expect(errors.Is(err, ErrUnknownField)).Should(BeTrue())
+			Expect(err.Error()).Should(HaveSuffix("Another, Other"))
+		})
+		It("t2", func() {
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data").Default(strShort1), test_NewStdColumn("Note"))
+			err := tb.AppendRowM(map[string]any{"ID": 1})
+			Expect(errors.Is(err, ErrFieldIsMissing)).Should(BeTrue())
+			tb.AllowMissingFields("-")
+			Expect(tb.AppendRowM(map[string]any{"ID": 1})).Should(BeNil())
+			Expect(tb.AppendRowM(map[string]any{"Note": strShort2})).Should(BeNil())
+			Expect(tb.Row(0)).Should(Equal([]any{1, strShort1, "-"}))
+			Expect(tb.Row(1)).Should(Equal([]any{"-", strShort1, strShort2}))
+		})
+		It("t3", func() {
+			tb := NewTable(nil).AllowMissingFields(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data"), test_NewStdColumn("Owner"))
+			nodes := []TreeNodeReader{
+				&mockTreeNode{ID: 1, Data: strShort1, children: []TreeNodeReader{&mockTreeNode{ID: 2, Data: strShort2}}},
+			}
+			Expect(tb.AppendTrees(*DefaultTreePathStyle(), nodes...)).Should(BeNil())
+			Expect(tb.RowStrings(1)).Should(Equal([]string{"  \\--", "2", strShort2, "<nil>"}))
+		})
+	})
+
+	Context("accessor", func() {
+		It("t1", func() {
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data").Hidden(true))
+			tb.AppendRow(1, strShort1)
+			tb.AppendRow(2, 3.5)
+			Expect(tb.RowCount()).Should(Equal(2))
+			cols := tb.Columns()
+			Expect(len(cols)).Should(Equal(2))
+			Expect(cols[1].Name()).Should(Equal("Data"))
+			Expect(tb.Row(1)).Should(Equal([]any{2, 3.5}))
+			Expect(tb.RowStrings(1)).Should(Equal([]string{"2", "3.5"}))
+			Expect(tb.RowMap(0)).Should(Equal(map[string]any{"ID": 1, "Data": strShort1}))
+			Expect(tb.Cell(1, 0).Data()).Should(Equal(strShort1))
+		})
+		It("t2", func() {
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"))
+			for i := 0; i < 5; i++ {
+				tb.AppendRow(i)
+			}
+			ids := []any{}
+			for i, row := range tb.All() {
+				if i == 3 {
+					break
+				}
+				ids = append(ids, row[0])
+			}
+			Expect(ids).Should(Equal([]any{0, 1, 2}))
+		})
+	})
+
+	Context("mutation", func() {
+		It("t1", func() {
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data"))
+			tb.AppendRow(1, strShort1)
+			tb.AppendRow(3, strShort2)
+			Expect(tb.InsertRow(1, 2, strHelloChinese)).Should(BeNil())
+			Expect(tb.InsertRow(3, 4, strSingle)).Should(BeNil())
+			Expect(errors.Is(tb.InsertRow(5, 5, strSingle), ErrIndexOutOfRange)).Should(BeTrue())
+			Expect(tb.DeleteRow(0)).Should(BeNil())
+			Expect(errors.Is(tb.DeleteRow(3), ErrIndexOutOfRange)).Should(BeTrue())
+			tb.RowStyle(1, Bold)
+			Expect(tb.UpdateRow(1, 30, strShort1)).Should(BeNil())
+			Expect(tb.UpdateRow(1, 30)).ShouldNot(BeNil())
+			Expect(tb.SetCellValue(2, "Data", strShort2)).Should(BeNil())
+			Expect(errors.Is(tb.SetCellValue(2, "Other", 1), ErrColumnNotExist)).Should(BeTrue())
+			Expect(tb.RowCount()).Should(Equal(3))
+			Expect(tb.Row(0)).Should(Equal([]any{2, strHelloChinese}))
+			Expect(tb.Row(1)).Should(Equal([]any{30, strShort1}))
+			Expect(tb.Row(2)).Should(Equal([]any{4, strShort2}))
+			Expect(tb.rows[1].style).Should(Equal([]TextStyle{Bold}))
+		})
+		It("t2", func() {
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data"))
+			tb.AppendRow(1, strShort1)
+			tb.AppendRow(2, strShort2)
+			Expect(tb.InsertColumn(1, test_NewStdColumn("Score"), 0)).Should(BeNil())
+			Expect(errors.Is(tb.InsertColumn(1, test_NewStdColumn("ID"), 0), ErrColumnAlreadyExist)).Should(BeTrue())
+			Expect(tb.SetCellValue(1, "Score", 9.5)).Should(BeNil())
+			Expect(tb.MoveColumn("ID", 2)).Should(BeNil())
+			Expect(tb.RenameColumn("Data", "Text")).Should(BeNil())
+			Expect(errors.Is(tb.RenameColumn("Text", "ID"), ErrColumnAlreadyExist)).Should(BeTrue())
+			Expect(tb.AppendRowM(map[string]any{"ID": 3, "Text": strSingle, "Score": 1})).Should(BeNil())
+			Expect(tb.RowMap(1)).Should(Equal(map[string]any{"Score": 9.5, "Text": strShort2, "ID": 2}))
+			Expect(tb.RemoveColumn("Score")).Should(BeNil())
+			Expect(errors.Is(tb.RemoveColumn("Score"), ErrColumnNotExist)).Should(BeTrue())
+			col, err := tb.GetColumn("ID")
+			Expect(err).Should(BeNil())
+			Expect(col.Name()).Should(Equal("ID"))
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				"+-------------+----+",
+				"|    Text     | ID |",
+				"+-------------+----+",
+				"| abcd        | 1  |",
+				"| ab cd ef gh | 2  |",
+				"| a           | 3  |",
+				"+-------------+----+",
+				"",
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+		})
+		It("t3", func() {
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data"))
+			tb.AppendRow(1, strShort1)
+			err := tb.AppendTrees(*DefaultTreePathStyle(), &mockTreeNode{ID: 2, Data: strShort2})
+			Expect(err).Should(BeNil())
+			col, err := tb.GetColumn("Data")
+			Expect(err).Should(BeNil())
+			Expect(col.Name()).Should(Equal("Data"))
+			Expect(tb.MoveColumn("Path", 2)).Should(BeNil())
+			Expect(tb.RowStrings(0)).Should(Equal([]string{"1", strShort1, ""}))
+			Expect(tb.RowStrings(1)).Should(Equal([]string{"2", strShort2, ">--"}))
+		})
+		It("t4", func() {
+			// tree paths follow mutations of rows of trees
+			roots, err := BuildTreeFromPaths([]map[string]any{{"path": "a/a1"}, {"path": "b/b1"}}, "path", "/", "name")
+			Expect(err).Should(BeNil())
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("name"))
+			sty := DefaultTreePathStyle()
+			sty.Label = "name"
+			Expect(tb.AppendTrees(*sty, roots...)).Should(BeNil())
+			Expect(tb.DeleteRow(2)).Should(BeNil())
+			Expect(tb.RowCount()).Should(Equal(2))
+			Expect(tb.InsertRow(1, "", "a0")).Should(BeNil())
+			Expect(tb.UpdateRow(2, "", "a2")).Should(BeNil())
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				`+----------+------+`,
+				`|   Path   | name |`,
+				`+----------+------+`,
+				`| >-+-- a  | a    |`,
+				`|   +-- a0 | a0   |`,
+				`|   \-- a2 | a2   |`,
+				`+----------+------+`,
+				``,
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+		})
 	})
 
 	Context("render-tree", func() {
@@ -214,6 +440,339 @@ var _ = Describe("Table Test Suites", func() {
 				Expect(line).Should(Equal(expects[i]))
 			}
 		})
+		It("t2", func() {
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"))
+			opts := TreeOptions{MaxDepth: 2, ElidedMarker: DefaultElidedMarker}
+			err := tb.AppendTreesWithOptions(*DefaultTreePathStyle().Header(), opts, test_NewMockTree()...)
+			Expect(err).To(BeNil())
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				`+-----------------+----+`,
+				`|      Path       | ID |`,
+				`+-----------------+----+`,
+				`| >-+--           | 1  |`,
+				`|   +-- (+1 more) | 2  |`,
+				`|   \--           | 3  |`,
+				`| >----           | 6  |`,
+				`+-----------------+----+`,
+				``,
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+		})
+		It("t3", func() {
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"))
+			opts := TreeOptions{
+				Collapse: func(n TreeNodeReader) bool {
+					return n.Fields()["ID"] == 1
+				},
+				ElidedMarker: "%d hidden",
+				ElidedAsRow:  true,
+			}
+			err := tb.AppendTreesWithOptions(*DefaultTreePathStyle().Header(), opts, test_NewMockTree()...)
+			Expect(err).To(BeNil())
+			Expect(tb.RowCount()).Should(Equal(3))
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				`+----------------+----+`,
+				`|      Path      | ID |`,
+				`+----------------+----+`,
+				`| >-+--          | 1  |`,
+				`|   \-- 2 hidden |    |`,
+				`| >----          | 6  |`,
+				`+----------------+----+`,
+				``,
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+		})
+		It("t4", func() {
+			// hidden children are not marked without a marker
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"))
+			err := tb.AppendTreesWithOptions(*DefaultTreePathStyle().Header(), TreeOptions{MaxDepth: 1}, test_NewMockTree()...)
+			Expect(err).To(BeNil())
+			Expect(tb.RowCount()).Should(Equal(2))
+			Expect(tb.RowStrings(0)).Should(Equal([]string{">--", "1"}))
+		})
+		It("t5", func() {
+			// labels are wrapped under the path when the table is narrow and the style wraps them
+			roots, err := BuildTreeFromPaths([]map[string]any{
+				{"path": "usr/share/documentation", "size": 1},
+				{"path": "usr/bin", "size": 2},
+			}, "path", "/", "name")
+			Expect(err).Should(BeNil())
+			l := DefaultTableLayout()
+			l.Width = 22
+			tb := NewTable(l).AllowMissingFields("")
+			tb.AppendColumn(test_NewStdColumn("size"))
+			sty := DefaultTreePathStyle().Header()
+			sty.Label = "name"
+			Expect(tb.AppendTrees(*sty, roots...)).Should(BeNil())
+			_, err = tb.Render(Console)
+			Expect(errors.Is(err, ErrRenderTableFailed)).Should(BeTrue())
+
+			tb = NewTable(l).AllowMissingFields("")
+			tb.AppendColumn(test_NewStdColumn("size"))
+			Expect(tb.AppendTrees(*sty.Overflow(Wordwrap), roots...)).Should(BeNil())
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				`+-------------+------+`,
+				`|    Path     | size |`,
+				`+-------------+------+`,
+				`| >-+---- usr |      |`,
+				`|   +-+-- sha |      |`,
+				`|   | |   re  |      |`,
+				`|   | \-- doc | 1    |`,
+				`|   |     ume |      |`,
+				`|   |     nta |      |`,
+				`|   |     tio |      |`,
+				`|   |     n   |      |`,
+				`|   \---- bin | 2    |`,
+				`+-------------+------+`,
+				``,
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+		})
+		It("t6", func() {
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"))
+			sty := DefaultTreePathStyle().Header()
+			sty.Indent = 4
+			sty.Label = "ID"
+			opts := TreeOptions{MaxDepth: 2, ElidedMarker: DefaultElidedMarker}
+			Expect(tb.AppendTreesWithOptions(*sty, opts, test_NewMockTree()...)).Should(BeNil())
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				`+-----------------------+----+`,
+				`|         Path          | ID |`,
+				`+-----------------------+----+`,
+				`| >---+---- 1           | 1  |`,
+				`|     +---- 2 (+1 more) | 2  |`,
+				`|     \---- 3           | 3  |`,
+				`| >-------- 6           | 6  |`,
+				`+-----------------------+----+`,
+				``,
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+		})
+		It("t7", func() {
+			// the tree path is never wrapped
+			l := DefaultTableLayout()
+			l.Width = 12
+			tb := NewTable(l)
+			tb.AppendColumn(test_NewStdColumn("ID"))
+			sty := DefaultTreePathStyle().Header()
+			sty.Label = "ID"
+			Expect(tb.AppendTrees(*sty, test_NewMockTree()...)).Should(BeNil())
+			_, err := tb.Render(Console)
+			Expect(errors.Is(err, ErrRenderTableFailed)).Should(BeTrue())
+
+			// labels are truncated or rejected by the overflow action of the style
+			node := NewTreeNode(map[string]any{"ID": 1, "name": "abcdef"})
+			sty.Label = "name"
+			sty.Name = "Tree"
+			sty.Overflow(Truncate)
+			tb = NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"))
+			Expect(tb.AppendTrees(*sty, node)).Should(BeNil())
+			col, err := tb.GetColumn("Tree")
+			Expect(err).Should(BeNil())
+			col.Width(10, false)
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			Expect(strings.Split(out, "\n")[3]).Should(Equal(`| >-- ab ~ | 1  |`))
+
+			sty.Overflow(Exception)
+			tb = NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"))
+			Expect(tb.AppendTrees(*sty, node)).Should(BeNil())
+			col, err = tb.GetColumn("Tree")
+			Expect(err).Should(BeNil())
+			col.Width(10, false)
+			_, err = tb.Render(Console)
+			Expect(errors.Is(err, ErrInsufficientColumnWidth)).Should(BeTrue())
+		})
+		It("t8", func() {
+			// labels follow connectors like the tree command
+			recs := []map[string]any{
+				{"path": "src/lib/a.go", "size": 1},
+				{"path": "src/main.go", "size": 2},
+				{"path": "go.mod", "size": 3},
+			}
+			roots, err := BuildTreeFromPaths(recs, "path", "/", "name")
+			Expect(err).Should(BeNil())
+			tb := NewTable(LightTableLayout()).AllowMissingFields("")
+			tb.AppendColumn(test_NewStdColumn("size"))
+			sty := TreeCommandTreePathStyle().Header()
+			sty.Label = "name"
+			Expect(tb.AppendTrees(*sty, roots...)).Should(BeNil())
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects := []string{
+				`┌──────────────┬──────┐`,
+				`│     Path     │ size │`,
+				`├──────────────┼──────┤`,
+				`│ src          │      │`,
+				`│ ├── lib      │      │`,
+				`│ │   └── a.go │ 1    │`,
+				`│ └── main.go  │ 2    │`,
+				`│ go.mod       │ 3    │`,
+				`└──────────────┴──────┘`,
+				``,
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+
+			roots, err = BuildTreeFromPaths(recs, "path", "/", "name")
+			Expect(err).Should(BeNil())
+			tb = NewTable(LightTableLayout()).AllowMissingFields("")
+			tb.AppendColumn(test_NewStdColumn("size"))
+			sty = IndentTreePathStyle().Header()
+			sty.Label = "name"
+			Expect(tb.AppendTrees(*sty, roots...)).Should(BeNil())
+			out, err = tb.Render(Console)
+			Expect(err).Should(BeNil())
+			expects = []string{
+				`┌───────────┬──────┐`,
+				`│   Path    │ size │`,
+				`├───────────┼──────┤`,
+				`│ src       │      │`,
+				`│   lib     │      │`,
+				`│     a.go  │ 1    │`,
+				`│   main.go │ 2    │`,
+				`│ go.mod    │ 3    │`,
+				`└───────────┴──────┘`,
+				``,
+			}
+			Expect(strings.Split(out, "\n")).Should(Equal(expects))
+		})
+		It("t9", func() {
+			// depth styles color entire rows under row styles and are reused for deeper rows
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"))
+			sty := HeavyTreePathStyle().Header().Body(Bold)
+			sty.DepthStyles = []TextStyle{Red, Green}
+			Expect(tb.AppendTrees(*sty, test_NewMockTree()...)).Should(BeNil())
+			tb.RowStyle(1, Underline)
+			Expect(tb.rows[0].cells[0].style).Should(Equal([]TextStyle{Bold}))
+			Expect(tb.rows[2].cells[0].String()).Should(Equal("  ┃ ┗━┳━━"))
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			lines := strings.Split(out, "\n")
+			Expect(lines[3]).Should(Equal("\033[31m|\033[39m\033[1;31m ■━┳━━━━━━ \033[22;39m\033[31m|\033[39m\033[31m 1  \033[39m\033[31m|\033[39m"))
+			Expect(lines[4]).Should(Equal("\033[4;32m|\033[24;39m\033[4;1;32m   ┣━┳━━━━ \033[24;22;39m\033[4;32m|\033[24;39m\033[4;32m 2  \033[24;39m\033[4;32m|\033[24;39m"))
+			Expect(lines[5]).Should(HavePrefix("\033[31m|"))
+			Expect(lines[8]).Should(HavePrefix("\033[31m|"))
+		})
+		It("t10", func() {
+			recs := []map[string]any{
+				{"path": "src/lib/a.go"},
+				{"path": "src/main.go"},
+				{"path": "go.mod"},
+			}
+			cases := []struct {
+				sty     *TreePathStyle
+				expects []string
+			}{
+				{DoubleTreePathStyle(), []string{"□═╦════ src", "  ╠═╦══ lib", "  ║ ╚══ a.go", "  ╚════ main.go", "□══════ go.mod"}},
+				{RoundedTreePathStyle(), []string{"○─┬──── src", "  ├─┬── lib", "  │ ╰── a.go", "  ╰──── main.go", "○────── go.mod"}},
+			}
+			for _, c := range cases {
+				roots, err := BuildTreeFromPaths(recs, "path", "/", "name")
+				Expect(err).Should(BeNil())
+				tb := NewTable(nil)
+				c.sty.Header().Label = "name"
+				Expect(tb.AppendTrees(*c.sty, roots...)).Should(BeNil())
+				out, err := tb.Render(Console)
+				Expect(err).Should(BeNil())
+				lines := strings.Split(out, "\n")[3:8]
+				for i := range lines {
+					lines[i] = strings.TrimRight(strings.TrimPrefix(lines[i], "| "), " |")
+				}
+				Expect(lines).Should(Equal(c.expects))
+			}
+
+			// empty glyphs are left alone when a path is continued
+			sty := TreeCommandTreePathStyle()
+			Expect(sty.ReplacePathAsExtention("│   ├──")).Should(Equal("│   │"))
+			Expect(IndentTreePathStyle().ReplacePathAsExtention("    ")).Should(Equal("    "))
+		})
+	})
+	Context("render-plan", func() {
+		It("t1", func() {
+			// rendering to a narrow width leaves columns unchanged
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data"))
+			tb.columns[1].HeaderStyle(DefauleHeaderStyle().Text().EscapeLineFeed(true))
+			tb.AppendRow(1, strLong)
+			wide, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+
+			l := DefaultTableLayout()
+			l.Width = 20
+			narrow, err := tb.RenderWithLayout(Console, l)
+			Expect(err).Should(BeNil())
+			for _, line := range strings.Split(strings.TrimSpace(narrow), "\n") {
+				Expect(line).Should(HaveLen(20))
+			}
+			Expect(tb.columns[1].widthLimit).Should(Equal(0))
+			Expect(tb.columns[1].autoWidthControl).Should(BeTrue())
+			Expect(tb.columns[1].header.escapeLineFeed).Should(BeTrue())
+			Expect(tb.Layout).Should(Equal(*DefaultTableLayout()))
+
+			out, err := tb.Render(Console)
+			Expect(err).Should(BeNil())
+			Expect(out).Should(Equal(wide))
+			tb.Layout.Width = 20
+			out, err = tb.Render(Console)
+			Expect(err).Should(BeNil())
+			Expect(out).Should(Equal(narrow))
+		})
+		It("t2", func() {
+			// a table is rendered to several widths and outputs concurrently
+			tb := NewTable(nil)
+			tb.AppendColumn(test_NewStdColumn("ID"), test_NewStdColumn("Data"))
+			Expect(tb.AppendTrees(*DefaultTreePathStyle(), test_NewMockTree()...)).Should(BeNil())
+			for i := range tb.RowCount() {
+				tb.Cell(2, i).Value(strLong)
+			}
+			layouts := []*TableLayout{nil}
+			for _, w := range []int{30, 40, 60} {
+				l := DefaultTableLayout()
+				l.Width = w
+				layouts = append(layouts, l)
+			}
+			outputs := []Output{Console, Json, Yaml, Html}
+			expects := map[[2]int]string{}
+			for i, l := range layouts {
+				for j, o := range outputs {
+					out, err := tb.RenderWithLayout(o, l)
+					Expect(err).Should(BeNil())
+					expects[[2]int{i, j}] = out
+				}
+			}
+			var wg sync.WaitGroup
+			results := make(map[[2]int]string)
+			var mu sync.Mutex
+			for i, l := range layouts {
+				for j, o := range outputs {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						out, _ := tb.RenderWithLayout(o, l)
+						mu.Lock()
+						results[[2]int{i, j}] = out
+						mu.Unlock()
+					}()
+				}
+			}
+			wg.Wait()
+			Expect(results).Should(Equal(expects))
+		})
 	})
 })
 
@@ -221,3 +780,21 @@ func test_NewStdColumn(name string) *Column {
 	// clean text style to simplify unit test
 	return NewStandardColumn(name).HeaderStyle(DefauleHeaderStyle().Text()).BodyStyle(DefauleBodyStyle().Text())
 }
+
+func test_NewMockTree() []TreeNodeReader {
+	return []TreeNodeReader{
+		&mockTreeNode{
+			ID: 1,
+			children: []TreeNodeReader{
+				&mockTreeNode{
+					ID: 2,
+					children: []TreeNodeReader{
+						&mockTreeNode{ID: 4, children: []TreeNodeReader{&mockTreeNode{ID: 5}}},
+					},
+				},
+				&mockTreeNode{ID: 3},
+			},
+		},
+		&mockTreeNode{ID: 6},
+	}
+}
@@ -0,0 +1,443 @@
+package gotable
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Theme bundles a table layout with the default column styles so that it can be shared as a JSON or YAML file
+type Theme struct {
+	Layout *TableLayout
+	Header *ColumnStyle
+	Body   *ColumnStyle
+}
+
+// LoadTheme reads a theme from JSON or YAML, the theme may inherit a registered layout with the "extends" key.
+// Fields which are not given are taken from DefaultTableLayout when the theme extends nothing.
+func LoadTheme(r io.Reader) (*Theme, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	out := &Theme{}
+	// JSON is a subset of YAML so a single decoder handles both formats
+	err = yaml.Unmarshal(b, out)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTheme, err)
+	}
+	// empty documents, null and documents of comments only are not decoded into the theme
+	if out.Layout == nil {
+		return nil, fmt.Errorf("%w: empty document", ErrInvalidTheme)
+	}
+	err = out.Layout.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LoadLayout reads a table layout from JSON or YAML, see LoadTheme
+func LoadLayout(r io.Reader) (*TableLayout, error) {
+	t, err := LoadTheme(r)
+	if err != nil {
+		return nil, err
+	}
+	return t.Layout, nil
+}
+
+// ApplyTheme sets the layout of the table and the styles of its columns, the header is rendered with the new style.
+// Cells that already exist are kept as they are, so that the body style applies to rows appended afterwards. The body
+// style is not applied to the tree path column.
+func (a *Table) ApplyTheme(th *Theme) {
+	if th.Layout != nil {
+		a.Layout = *th.Layout
+	}
+	for _, col := range a.columns {
+		if th.Header != nil {
+			col.HeaderStyle(th.Header)
+			col.header.text = slices.Clone(th.Header.text)
+		}
+		if _, ok := col.columnCellMaker.(*TreePathColumn); ok || th.Body == nil {
+			continue
+		}
+		col.BodyStyle(th.Body)
+		col.body.text = slices.Clone(th.Body.text)
+	}
+}
+
+type themeDoc struct {
+	layoutDoc `yaml:",inline"`
+	Header    *columnStyleDoc `json:"header,omitempty" yaml:"header,omitempty"`
+	Body      *columnStyleDoc `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+func (a Theme) doc() themeDoc {
+	d := themeDoc{}
+	if a.Layout != nil {
+		d.layoutDoc = a.Layout.doc()
+	}
+	if a.Header != nil {
+		tmp := a.Header.doc()
+		d.Header = &tmp
+	}
+	if a.Body != nil {
+		tmp := a.Body.doc()
+		d.Body = &tmp
+	}
+	return d
+}
+
+func (a *Theme) fromDoc(d themeDoc) error {
+	l, err := d.layoutDoc.layout()
+	if err != nil {
+		return err
+	}
+	a.Layout = l
+	a.Header = d.Header.columnStyle(DefauleHeaderStyle())
+	a.Body = d.Body.columnStyle(DefauleBodyStyle())
+	return nil
+}
+
+func (a Theme) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.doc())
+}
+
+func (a *Theme) UnmarshalJSON(b []byte) error {
+	d := themeDoc{}
+	err := json.Unmarshal(b, &d)
+	if err != nil {
+		return err
+	}
+	return a.fromDoc(d)
+}
+
+func (a Theme) MarshalYAML() (any, error) {
+	return a.doc(), nil
+}
+
+func (a *Theme) UnmarshalYAML(n *yaml.Node) error {
+	d := themeDoc{}
+	err := n.Decode(&d)
+	if err != nil {
+		return err
+	}
+	return a.fromDoc(d)
+}
+
+// layoutDoc is the file representation of TableLayout, fields share names with TableLayout and are left nil when not given
+type layoutDoc struct {
+	Extends                string       `json:"extends,omitempty" yaml:"extends,omitempty"`
+	HeaderTopLeft          *runeText    `json:"header_top_left,omitempty" yaml:"header_top_left,omitempty"`
+	HeaderTopRight         *runeText    `json:"header_top_right,omitempty" yaml:"header_top_right,omitempty"`
+	HeaderTopSeparator     *runeText    `json:"header_top_separator,omitempty" yaml:"header_top_separator,omitempty"`
+	HeaderTopHorizontal    *runeText    `json:"header_top_horizontal,omitempty" yaml:"header_top_horizontal,omitempty"`
+	HeaderLeft             *runeText    `json:"header_left,omitempty" yaml:"header_left,omitempty"`
+	HeaderRight            *runeText    `json:"header_right,omitempty" yaml:"header_right,omitempty"`
+	HeaderSeparator        *runeText    `json:"header_separator,omitempty" yaml:"header_separator,omitempty"`
+	HeaderBottomLeft       *runeText    `json:"header_bottom_left,omitempty" yaml:"header_bottom_left,omitempty"`
+	HeaderBottomRight      *runeText    `json:"header_bottom_right,omitempty" yaml:"header_bottom_right,omitempty"`
+	HeaderBottomSeparator  *runeText    `json:"header_bottom_separator,omitempty" yaml:"header_bottom_separator,omitempty"`
+	HeaderBottomHorizontal *runeText    `json:"header_bottom_horizontal,omitempty" yaml:"header_bottom_horizontal,omitempty"`
+	BodyTopLeft            *runeText    `json:"body_top_left,omitempty" yaml:"body_top_left,omitempty"`
+	BodyTopRight           *runeText    `json:"body_top_right,omitempty" yaml:"body_top_right,omitempty"`
+	BodyTopSeparator       *runeText    `json:"body_top_separator,omitempty" yaml:"body_top_separator,omitempty"`
+	BodyTopHorizontal      *runeText    `json:"body_top_horizontal,omitempty" yaml:"body_top_horizontal,omitempty"`
+	BodyBottomLeft         *runeText    `json:"body_bottom_left,omitempty" yaml:"body_bottom_left,omitempty"`
+	BodyBottomRight        *runeText    `json:"body_bottom_right,omitempty" yaml:"body_bottom_right,omitempty"`
+	BodyBottomSeparator    *runeText    `json:"body_bottom_separator,omitempty" yaml:"body_bottom_separator,omitempty"`
+	BodyBottomHorizontal   *runeText    `json:"body_bottom_horizontal,omitempty" yaml:"body_bottom_horizontal,omitempty"`
+	RowLeft                *runeText    `json:"row_left,omitempty" yaml:"row_left,omitempty"`
+	RowRight               *runeText    `json:"row_right,omitempty" yaml:"row_right,omitempty"`
+	RowSeparator           *runeText    `json:"row_separator,omitempty" yaml:"row_separator,omitempty"`
+	RowHorizontal          *runeText    `json:"row_horizontal,omitempty" yaml:"row_horizontal,omitempty"`
+	ColumnSeparator        *runeText    `json:"column_separator,omitempty" yaml:"column_separator,omitempty"`
+	ColumnPaddingLeft      *string      `json:"column_padding_left,omitempty" yaml:"column_padding_left,omitempty"`
+	ColumnPaddingRight     *string      `json:"column_padding_right,omitempty" yaml:"column_padding_right,omitempty"`
+	CellPadding            *string      `json:"cell_padding,omitempty" yaml:"cell_padding,omitempty"`
+	RowSeparatorLeft       *runeText    `json:"row_separator_left,omitempty" yaml:"row_separator_left,omitempty"`
+	RowSeparatorRight      *runeText    `json:"row_separator_right,omitempty" yaml:"row_separator_right,omitempty"`
+	ShowHeader             *bool        `json:"show_header,omitempty" yaml:"show_header,omitempty"`
+	ShowHeaderTopBorder    *bool        `json:"show_header_top_border,omitempty" yaml:"show_header_top_border,omitempty"`
+	ShowHeaderBottemBorder *bool        `json:"show_header_bottom_border,omitempty" yaml:"show_header_bottom_border,omitempty"`
+	ShowBodyTopBorder      *bool        `json:"show_body_top_border,omitempty" yaml:"show_body_top_border,omitempty"`
+	ShowBodyBottomBorder   *bool        `json:"show_body_bottom_border,omitempty" yaml:"show_body_bottom_border,omitempty"`
+	ShowSideBorder         *bool        `json:"show_side_border,omitempty" yaml:"show_side_border,omitempty"`
+	ShowColumnSeparator    *bool        `json:"show_column_separator,omitempty" yaml:"show_column_separator,omitempty"`
+	ShowRowSeparator       *bool        `json:"show_row_separator,omitempty" yaml:"show_row_separator,omitempty"`
+	StripeStyle            *[]TextStyle `json:"stripe_style,omitempty" yaml:"stripe_style,omitempty"`
+	Width                  *int         `json:"width,omitempty" yaml:"width,omitempty"`
+}
+
+func (a TableLayout) doc() layoutDoc {
+	d := layoutDoc{}
+	src := reflect.ValueOf(a)
+	dst := reflect.ValueOf(&d).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		f := dst.FieldByName(src.Type().Field(i).Name)
+		if !f.IsValid() {
+			continue
+		}
+		ptr := reflect.New(f.Type().Elem())
+		ptr.Elem().Set(src.Field(i).Convert(f.Type().Elem()))
+		f.Set(ptr)
+	}
+	// sides of row separators are optional
+	if a.RowSeparatorLeft == 0 {
+		d.RowSeparatorLeft = nil
+	}
+	if a.RowSeparatorRight == 0 {
+		d.RowSeparatorRight = nil
+	}
+	return d
+}
+
+// layout creates a table layout from the extended layout, or the default layout when nothing is extended, and overrides
+// it with the fields that are given
+func (a layoutDoc) layout() (*TableLayout, error) {
+	out := DefaultTableLayout()
+	if a.Extends != "" {
+		l, err := GetTableLayout(a.Extends)
+		if err != nil {
+			return nil, err
+		}
+		out = l
+	}
+	src := reflect.ValueOf(a)
+	dst := reflect.ValueOf(out).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		f := src.Field(i)
+		if f.Kind() != reflect.Pointer || f.IsNil() {
+			continue
+		}
+		tmp := dst.FieldByName(src.Type().Field(i).Name)
+		tmp.Set(f.Elem().Convert(tmp.Type()))
+	}
+	return out, nil
+}
+
+func (a TableLayout) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.doc())
+}
+
+func (a *TableLayout) UnmarshalJSON(b []byte) error {
+	d := layoutDoc{}
+	err := json.Unmarshal(b, &d)
+	if err != nil {
+		return err
+	}
+	l, err := d.layout()
+	if err != nil {
+		return err
+	}
+	*a = *l
+	return nil
+}
+
+func (a TableLayout) MarshalYAML() (any, error) {
+	return a.doc(), nil
+}
+
+func (a *TableLayout) UnmarshalYAML(n *yaml.Node) error {
+	d := layoutDoc{}
+	err := n.Decode(&d)
+	if err != nil {
+		return err
+	}
+	l, err := d.layout()
+	if err != nil {
+		return err
+	}
+	*a = *l
+	return nil
+}
+
+type columnStyleDoc struct {
+	OverFlowAction *ColumnOverFlowAction `json:"overflow,omitempty" yaml:"overflow,omitempty"`
+	EscapeLineFeed *bool                 `json:"escape_line_feed,omitempty" yaml:"escape_line_feed,omitempty"`
+	Align          *Align                `json:"align,omitempty" yaml:"align,omitempty"`
+	Text           *[]TextStyle          `json:"text,omitempty" yaml:"text,omitempty"`
+}
+
+func (a ColumnStyle) doc() columnStyleDoc {
+	return columnStyleDoc{
+		OverFlowAction: &a.overFlowAction,
+		EscapeLineFeed: &a.escapeLineFeed,
+		Align:          &a.align,
+		Text:           &a.text,
+	}
+}
+
+// columnStyle overrides the base style with the fields that are given
+func (a *columnStyleDoc) columnStyle(base *ColumnStyle) *ColumnStyle {
+	if a == nil {
+		return base
+	}
+	if a.OverFlowAction != nil {
+		base.overFlowAction = *a.OverFlowAction
+	}
+	if a.EscapeLineFeed != nil {
+		base.escapeLineFeed = *a.EscapeLineFeed
+	}
+	if a.Align != nil {
+		base.align = *a.Align
+	}
+	if a.Text != nil {
+		base.text = *a.Text
+	}
+	return base
+}
+
+func (a ColumnStyle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.doc())
+}
+
+func (a *ColumnStyle) UnmarshalJSON(b []byte) error {
+	d := columnStyleDoc{}
+	err := json.Unmarshal(b, &d)
+	if err != nil {
+		return err
+	}
+	d.columnStyle(a)
+	return nil
+}
+
+func (a ColumnStyle) MarshalYAML() (any, error) {
+	return a.doc(), nil
+}
+
+func (a *ColumnStyle) UnmarshalYAML(n *yaml.Node) error {
+	d := columnStyleDoc{}
+	err := n.Decode(&d)
+	if err != nil {
+		return err
+	}
+	d.columnStyle(a)
+	return nil
+}
+
+// runeText is a rune that is written as a single character string
+type runeText rune
+
+func (a runeText) MarshalText() ([]byte, error) {
+	return []byte(string(rune(a))), nil
+}
+
+func (a *runeText) UnmarshalText(b []byte) error {
+	rs := []rune(string(b))
+	if len(rs) != 1 {
+		return fmt.Errorf("%w: %q is not a single character", ErrInvalidTheme, string(b))
+	}
+	*a = runeText(rs[0])
+	return nil
+}
+
+var alignNames = []string{"default", "left", "center", "justify", "right"}
+
+var overFlowActionNames = []string{"wordwrap", "truncate", "exception"}
+
+var textStyleNames = []string{
+	"none", "bold", "red", "green", "yellow", "blue", "bg-red", "bg-green", "bg-yellow", "bg-blue",
+	"italic", "underline", "dim", "blink", "reverse", "strikethrough",
+	"black", "magenta", "cyan", "white", "bg-black", "bg-magenta", "bg-cyan", "bg-white",
+	"bright-black", "bright-red", "bright-green", "bright-yellow", "bright-blue", "bright-magenta", "bright-cyan", "bright-white",
+	"bg-bright-black", "bg-bright-red", "bg-bright-green", "bg-bright-yellow", "bg-bright-blue", "bg-bright-magenta", "bg-bright-cyan", "bg-bright-white",
+}
+
+func (a Align) MarshalText() ([]byte, error) {
+	if a < 0 || int(a) >= len(alignNames) {
+		return nil, fmt.Errorf("%w: unknown align %d", ErrInvalidTheme, a)
+	}
+	return []byte(alignNames[a]), nil
+}
+
+func (a *Align) UnmarshalText(b []byte) error {
+	i, err := parseEnumName(alignNames, "align", string(b))
+	*a = Align(i)
+	return err
+}
+
+func (a ColumnOverFlowAction) MarshalText() ([]byte, error) {
+	if a < 0 || int(a) >= len(overFlowActionNames) {
+		return nil, fmt.Errorf("%w: unknown overflow action %d", ErrInvalidTheme, a)
+	}
+	return []byte(overFlowActionNames[a]), nil
+}
+
+func (a *ColumnOverFlowAction) UnmarshalText(b []byte) error {
+	i, err := parseEnumName(overFlowActionNames, "overflow action", string(b))
+	*a = ColumnOverFlowAction(i)
+	return err
+}
+
+// String returns the name of the text style, e.g. "bold", "bg-red", "color-208" or "bg-#ff8800"
+func (a TextStyle) String() string {
+	payload := a & textStylePayloadMask
+	switch a &^ textStylePayloadMask {
+	case textStyleFg256:
+		return fmt.Sprintf("color-%d", int(payload))
+	case textStyleBg256:
+		return fmt.Sprintf("bg-color-%d", int(payload))
+	case textStyleFgRGB:
+		return fmt.Sprintf("#%06x", int(payload))
+	case textStyleBgRGB:
+		return fmt.Sprintf("bg-#%06x", int(payload))
+	}
+	if a >= 0 && int(a) < len(textStyleNames) {
+		return textStyleNames[a]
+	}
+	return fmt.Sprintf("TextStyle(%d)", int(a))
+}
+
+func (a TextStyle) MarshalText() ([]byte, error) {
+	if _, _, _, ok := a.sgr(); !ok && a != None {
+		return nil, fmt.Errorf("%w: unknown text style %d", ErrInvalidTheme, a)
+	}
+	return []byte(a.String()), nil
+}
+
+func (a *TextStyle) UnmarshalText(b []byte) error {
+	s := strings.ToLower(strings.TrimSpace(string(b)))
+	bg := strings.HasPrefix(s, "bg-")
+	v := strings.TrimPrefix(s, "bg-")
+	switch {
+	case strings.HasPrefix(v, "#") && len(v) == 7:
+		n, err := strconv.ParseUint(v[1:], 16, 32)
+		if err != nil {
+			return fmt.Errorf("%w: invalid color %q", ErrInvalidTheme, s)
+		}
+		*a = RGB(uint8(n>>16), uint8(n>>8), uint8(n))
+		if bg {
+			*a = BgRGB(uint8(n>>16), uint8(n>>8), uint8(n))
+		}
+		return nil
+	case strings.HasPrefix(v, "color-"):
+		n, err := strconv.ParseUint(strings.TrimPrefix(v, "color-"), 10, 8)
+		if err != nil {
+			return fmt.Errorf("%w: invalid color %q", ErrInvalidTheme, s)
+		}
+		*a = Color256(uint8(n))
+		if bg {
+			*a = BgColor256(uint8(n))
+		}
+		return nil
+	}
+	i, err := parseEnumName(textStyleNames, "text style", s)
+	*a = TextStyle(i)
+	return err
+}
+
+func parseEnumName(names []string, kind string, s string) (int, error) {
+	for i, name := range names {
+		if strings.EqualFold(name, strings.TrimSpace(s)) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown %s %q", ErrInvalidTheme, kind, s)
+}
@@ -0,0 +1,115 @@
+package gotable
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+)
+
+var _ = Describe("Theme Test Suites", func() {
+	It("load-case1", func() {
+		doc := `
+extends: light
+header_top_left: "╭"
+header_top_right: "╮"
+stripe_style: [dim, bg-color-236]
+width: 80
+header:
+  align: left
+body:
+  align: right
+  text: [bold, "#ff8800"]
+`
+		th, err := LoadTheme(strings.NewReader(doc))
+		Expect(err).Should(BeNil())
+		expects := LightTableLayout()
+		expects.HeaderTopLeft = '╭'
+		expects.HeaderTopRight = '╮'
+		expects.StripeStyle = []TextStyle{Dim, BgColor256(236)}
+		expects.Width = 80
+		Expect(th.Layout).Should(Equal(expects))
+		Expect(th.Header).Should(Equal(DefauleHeaderStyle().Align(AlignLeft)))
+		Expect(th.Body).Should(Equal(DefauleBodyStyle().Align(AlignRight).Text(Bold, RGB(0xff, 0x88, 0))))
+	})
+	It("load-case2", func() {
+		l, err := LoadLayout(strings.NewReader(`{"extends": "heavy", "show_row_separator": true, "row_horizontal": "-"}`))
+		Expect(err).Should(BeNil())
+		expects := HeavyTableLayout()
+		expects.ShowRowSeparator = true
+		expects.RowHorizontal = '-'
+		Expect(l).Should(Equal(expects))
+	})
+	It("load-case3", func() {
+		_, err := LoadLayout(strings.NewReader(`extends: not-exist`))
+		Expect(errors.Is(err, ErrLayoutNotExist)).Should(BeTrue())
+		_, err = LoadLayout(strings.NewReader(`{"extends": "light", "column_separator": "||"}`))
+		Expect(errors.Is(err, ErrInvalidTheme)).Should(BeTrue())
+		_, err = LoadLayout(strings.NewReader(`{"extends": "light", "column_separator": "丨"}`))
+		Expect(errors.Is(err, ErrInvalidLayout)).Should(BeTrue())
+		_, err = LoadTheme(strings.NewReader(`{"extends": "light", "body": {"text": ["sparkle"]}}`))
+		Expect(errors.Is(err, ErrInvalidTheme)).Should(BeTrue())
+	})
+	It("save-case1", func() {
+		th := Theme{
+			Layout: DoubleTableLayout().StripeRows(Italic, BgBrightBlack),
+			Header: DefauleHeaderStyle(),
+			Body:   DefauleBodyStyle().OverFlowAction(Truncate).Text(BgRGB(1, 2, 3)),
+		}
+		b, err := json.Marshal(th)
+		Expect(err).Should(BeNil())
+		Expect(string(b)).Should(ContainSubstring(`"header_top_left":"╔"`))
+		Expect(string(b)).Should(ContainSubstring(`"text":["bg-#010203"]`))
+		out, err := LoadTheme(bytes.NewReader(b))
+		Expect(err).Should(BeNil())
+		Expect(*out).Should(Equal(th))
+
+		b, err = yaml.Marshal(th)
+		Expect(err).Should(BeNil())
+		Expect(string(b)).Should(ContainSubstring("overflow: truncate"))
+		out, err = LoadTheme(bytes.NewReader(b))
+		Expect(err).Should(BeNil())
+		Expect(*out).Should(Equal(th))
+	})
+	It("load-case4", func() {
+		// documents without a layout are rejected
+		for _, doc := range []string{"", "null", "# comment only\n"} {
+			_, err := LoadTheme(strings.NewReader(doc))
+			Expect(errors.Is(err, ErrInvalidTheme)).Should(BeTrue(), doc)
+		}
+		// fields which are not given are taken from the default layout
+		l, err := LoadLayout(strings.NewReader(`width: 40`))
+		Expect(err).Should(BeNil())
+		expects := DefaultTableLayout()
+		expects.Width = 40
+		Expect(l).Should(Equal(expects))
+	})
+	It("apply-case1", func() {
+		th, err := LoadTheme(strings.NewReader(`{"extends": "light", "header": {"text": []}, "body": {"align": "right"}}`))
+		Expect(err).Should(BeNil())
+		tb := NewTable(nil)
+		tb.AppendColumn(NewStandardColumn("ID"), NewStandardColumn("Name"))
+		Expect(tb.AppendRow(1, "abc")).Should(BeNil())
+		tb.Cell(1, 0).Style(Bold)
+		tb.ApplyTheme(th)
+		// existing cells are kept
+		Expect(tb.Cell(1, 0).style).Should(Equal([]TextStyle{Bold}))
+		Expect(tb.AppendRow(2, "de")).Should(BeNil())
+		out, err := tb.Render(Console)
+		Expect(err).Should(BeNil())
+		expects := []string{
+			`┌────┬──────┐`,
+			`│ ID │ Name │`,
+			`├────┼──────┤`,
+			"│ 1  │\033[1m abc  \033[22m│",
+			`│  2 │   de │`,
+			`└────┴──────┘`,
+			``,
+		}
+		Expect(strings.Split(out, "\n")).Should(Equal(expects))
+	})
+})
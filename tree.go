@@ -0,0 +1,224 @@
+package gotable
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// TreeNode is a TreeNodeReader which holds fields of a record and its children
+type TreeNode struct {
+	fields   map[string]any
+	children []TreeNodeReader
+}
+
+func NewTreeNode(fields map[string]any) *TreeNode {
+	return &TreeNode{
+		fields:   fields,
+		children: []TreeNodeReader{},
+	}
+}
+
+func (a *TreeNode) AppendChildren(ns ...TreeNodeReader) *TreeNode {
+	a.children = append(a.children, ns...)
+	return a
+}
+
+func (a *TreeNode) Children() []TreeNodeReader {
+	return a.children
+}
+
+func (a *TreeNode) Fields() map[string]any {
+	return a.fields
+}
+
+// Tree is a TreeNodeReader which adapts a value of any type with accessors, see TreeOf and TreeOfSeq
+type Tree[T any] struct {
+	value    T
+	children func(T) iter.Seq[T]
+	fields   map[string]func(T) any
+}
+
+// TreeOf adapts a tree of values of any type, children returns children of a value and fields are accessors of
+// columns keyed by names of fields. Children are loaded when they are shown or counted by an elided marker, so that
+// nodes beyond TreeOptions.MaxDepth and below collapsed nodes are never loaded. Aggregated columns, see
+// Column.Aggregate, force a walk of the whole tree. Loaded children are not kept by the tree, so that memory is only
+// held by rows of the table. A nil children func makes a single node.
+func TreeOf[T any](root T, children func(T) []T, fields map[string]func(T) any) *Tree[T] {
+	var seq func(T) iter.Seq[T]
+	if children != nil {
+		seq = func(v T) iter.Seq[T] {
+			return slices.Values(children(v))
+		}
+	}
+	return TreeOfSeq(root, seq, fields)
+}
+
+// TreeOfSeq is like TreeOf with children yielded by an iterator, e.g. entries of a directory read on demand
+func TreeOfSeq[T any](root T, children func(T) iter.Seq[T], fields map[string]func(T) any) *Tree[T] {
+	return &Tree[T]{
+		value:    root,
+		children: children,
+		fields:   fields,
+	}
+}
+
+func (a *Tree[T]) Value() T {
+	return a.value
+}
+
+// Children loads children of the node every time it is called
+func (a *Tree[T]) Children() []TreeNodeReader {
+	out := []TreeNodeReader{}
+	if a.children == nil {
+		return out
+	}
+	for v := range a.children(a.value) {
+		out = append(out, TreeOfSeq(v, a.children, a.fields))
+	}
+	return out
+}
+
+// Fields returns values of all accessors, rows of tables are built by calling only the accessors of their columns
+func (a *Tree[T]) Fields() map[string]any {
+	out := make(map[string]any, len(a.fields))
+	for k, f := range a.fields {
+		out[k] = f(a.value)
+	}
+	return out
+}
+
+func (a *Tree[T]) lookupKey(key string) (any, bool) {
+	f, ok := a.fields[key]
+	if !ok {
+		return nil, false
+	}
+	return f(a.value), true
+}
+
+func (a *Tree[T]) fieldKeys() iter.Seq[string] {
+	return maps.Keys(a.fields)
+}
+
+// BuildTree links flat records by the id and the parent id and returns the roots in the order of records,
+// e.g. BuildTree(records, "id", "parent_id"). Records without the parent id, or with a nil or empty one are roots.
+// Ids are compared by their text so that 1 and "1" are the same.
+func BuildTree(records []map[string]any, idKey, parentKey string) ([]TreeNodeReader, error) {
+	nodes := make(map[string]*TreeNode, len(records))
+	ids := make([]string, len(records))
+	for i, rec := range records {
+		v, ok := rec[idKey]
+		if !ok || v == nil {
+			return nil, fmt.Errorf("%w: %s of record %d", ErrFieldIsMissing, idKey, i)
+		}
+		ids[i] = fmt.Sprint(v)
+		if _, ok := nodes[ids[i]]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateTreeNode, ids[i])
+		}
+		nodes[ids[i]] = NewTreeNode(rec)
+	}
+
+	roots := []TreeNodeReader{}
+	for i, rec := range records {
+		node := nodes[ids[i]]
+		pid := ""
+		if v, ok := rec[parentKey]; ok && v != nil {
+			pid = fmt.Sprint(v)
+		}
+		if pid == "" {
+			roots = append(roots, node)
+			continue
+		}
+		parent, ok := nodes[pid]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s of %s", ErrOrphanTreeNode, pid, ids[i])
+		}
+		parent.AppendChildren(node)
+	}
+
+	// every node has a parent at this point, so nodes which cannot be reached from roots are in cycles
+	visited := make(map[TreeNodeReader]bool, len(records))
+	var visit func(n TreeNodeReader)
+	visit = func(n TreeNodeReader) {
+		visited[n] = true
+		for _, c := range n.Children() {
+			visit(c)
+		}
+	}
+	for _, r := range roots {
+		visit(r)
+	}
+	for _, id := range ids {
+		if !visited[nodes[id]] {
+			return nil, fmt.Errorf("%w: %s", ErrTreeCycle, id)
+		}
+	}
+	return roots, nil
+}
+
+// BuildTreeFromPaths builds trees from records holding a path like "usr/local/bin", nodes are created for
+// intermediate paths which have no records. Paths of all nodes are stored in the same form, empty segments are removed
+// and the leading separator is kept, e.g. "/usr//local/" is stored as "/usr/local" and its parent is "/usr". Paths with
+// and without the leading separator belong to different trees, e.g. "usr/bin" is not a child of "/usr". When
+// nameKey is not empty, the last segment of the path is set to the field of every node which does not have the field
+// yet. Records are not modified, nodes hold copies of records whose fields are changed.
+func BuildTreeFromPaths(records []map[string]any, pathKey, sep, nameKey string) ([]TreeNodeReader, error) {
+	nodes := map[string]*TreeNode{}
+	fromRecord := map[string]bool{}
+	roots := []TreeNodeReader{}
+	for i, rec := range records {
+		v, ok := rec[pathKey].(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s of record %d", ErrFieldIsMissing, pathKey, i)
+		}
+		segs := []string{}
+		for _, s := range strings.Split(v, sep) {
+			if s != "" {
+				segs = append(segs, s)
+			}
+		}
+		if len(segs) == 0 {
+			return nil, fmt.Errorf("%w: %s of record %d", ErrFieldIsMissing, pathKey, i)
+		}
+		lead := ""
+		if strings.HasPrefix(v, sep) {
+			lead = sep
+		}
+		path := lead + strings.Join(segs, sep)
+		if fromRecord[path] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateTreeNode, path)
+		}
+		fromRecord[path] = true
+
+		// create or reuse nodes of every segment
+		var parent *TreeNode
+		for j := range segs {
+			p := lead + strings.Join(segs[:j+1], sep)
+			node, ok := nodes[p]
+			if !ok {
+				node = NewTreeNode(map[string]any{pathKey: p})
+				nodes[p] = node
+				if parent == nil {
+					roots = append(roots, node)
+				} else {
+					parent.AppendChildren(node)
+				}
+			}
+			if j == len(segs)-1 {
+				_, hasName := rec[nameKey]
+				node.fields = rec
+				if v != path || nameKey != "" && !hasName {
+					node.fields = maps.Clone(rec)
+					node.fields[pathKey] = path
+				}
+			}
+			if _, ok := node.fields[nameKey]; nameKey != "" && !ok {
+				node.fields[nameKey] = segs[j]
+			}
+			parent = node
+		}
+	}
+	return roots, nil
+}
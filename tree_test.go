@@ -0,0 +1,216 @@
+package gotable
+
+import (
+	"errors"
+	"iter"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tree Test Suites", func() {
+	It("build-case1", func() {
+		records := []map[string]any{
+			{"id": 1, "name": "ceo"},
+			{"id": 2, "parent_id": 1, "name": "cto"},
+			{"id": 3, "parent_id": "2", "name": "dev"},
+			{"id": 4, "parent_id": 1.0, "name": "cfo"},
+			{"id": 5, "parent_id": nil, "name": "board"},
+		}
+		roots, err := BuildTree(records, "id", "parent_id")
+		Expect(err).Should(BeNil())
+		Expect(roots).Should(HaveLen(2))
+		Expect(roots[0].Fields()["name"]).Should(Equal("ceo"))
+		Expect(roots[1].Fields()["name"]).Should(Equal("board"))
+		Expect(roots[0].Children()).Should(HaveLen(2))
+		Expect(roots[0].Children()[0].Children()[0].Fields()["name"]).Should(Equal("dev"))
+
+		tb := NewTable(LightTableLayout())
+		tb.AppendColumn(test_NewStdColumn("name"))
+		Expect(tb.AppendTrees(*DefaultTreePathStyle(), roots...)).Should(BeNil())
+		Expect(tb.RowCount()).Should(Equal(5))
+		Expect(tb.RowStrings(2)[1]).Should(Equal("dev"))
+	})
+	It("build-case2", func() {
+		_, err := BuildTree([]map[string]any{{"id": 1}, {"id": 2, "parent_id": 3}}, "id", "parent_id")
+		Expect(errors.Is(err, ErrOrphanTreeNode)).Should(BeTrue())
+		_, err = BuildTree([]map[string]any{{"id": 1}, {"id": 2, "parent_id": 3}, {"id": 3, "parent_id": 2}}, "id", "parent_id")
+		Expect(errors.Is(err, ErrTreeCycle)).Should(BeTrue())
+		_, err = BuildTree([]map[string]any{{"id": 1, "parent_id": 1}}, "id", "parent_id")
+		Expect(errors.Is(err, ErrTreeCycle)).Should(BeTrue())
+		_, err = BuildTree([]map[string]any{{"id": 1}, {"id": "1"}}, "id", "parent_id")
+		Expect(errors.Is(err, ErrDuplicateTreeNode)).Should(BeTrue())
+		_, err = BuildTree([]map[string]any{{"name": "x"}}, "id", "parent_id")
+		Expect(errors.Is(err, ErrFieldIsMissing)).Should(BeTrue())
+	})
+	It("build-case3", func() {
+		records := []map[string]any{
+			{"path": "/usr/local/bin/go", "size": 10},
+			{"path": "/usr//local/", "size": 20},
+			{"path": "/etc/hosts", "size": 1},
+		}
+		roots, err := BuildTreeFromPaths(records, "path", "/", "name")
+		Expect(err).Should(BeNil())
+		Expect(roots).Should(HaveLen(2))
+		usr := roots[0]
+		Expect(usr.Fields()).Should(Equal(map[string]any{"path": "/usr", "name": "usr"}))
+		local := usr.Children()[0]
+		Expect(local.Fields()).Should(Equal(map[string]any{"path": "/usr/local", "size": 20, "name": "local"}))
+		Expect(local.Children()[0].Fields()["path"]).Should(Equal("/usr/local/bin"))
+		Expect(local.Children()[0].Children()[0].Fields()["name"]).Should(Equal("go"))
+		Expect(roots[1].Children()[0].Fields()["size"]).Should(Equal(1))
+		// records are not modified
+		Expect(records[1]).Should(Equal(map[string]any{"path": "/usr//local/", "size": 20}))
+
+		tb := NewTable(LightTableLayout()).AllowMissingFields("")
+		tb.AppendColumn(test_NewStdColumn("name"), test_NewStdColumn("size"))
+		Expect(tb.AppendTrees(*DefaultTreePathStyle(), roots...)).Should(BeNil())
+		names := []string{}
+		for i := range tb.RowCount() {
+			names = append(names, tb.RowStrings(i)[1])
+		}
+		Expect(strings.Join(names, ",")).Should(Equal("usr,local,bin,go,etc,hosts"))
+	})
+	It("build-case4", func() {
+		_, err := BuildTreeFromPaths([]map[string]any{{"path": "a/b"}, {"path": "a//b/"}}, "path", "/", "")
+		Expect(errors.Is(err, ErrDuplicateTreeNode)).Should(BeTrue())
+		_, err = BuildTreeFromPaths([]map[string]any{{"path": "/"}}, "path", "/", "")
+		Expect(errors.Is(err, ErrFieldIsMissing)).Should(BeTrue())
+
+		// absolute and relative paths are not merged regardless of the order of records
+		for _, records := range [][]map[string]any{
+			{{"path": "/usr/bin"}, {"path": "usr/lib"}},
+			{{"path": "usr/lib"}, {"path": "/usr/bin"}},
+		} {
+			roots, err := BuildTreeFromPaths(records, "path", "/", "")
+			Expect(err).Should(BeNil())
+			Expect(roots).Should(HaveLen(2))
+			paths := map[string]any{}
+			for _, root := range roots {
+				paths[root.Fields()["path"].(string)] = root.Children()[0].Fields()["path"]
+			}
+			Expect(paths).Should(Equal(map[string]any{"/usr": "/usr/bin", "usr": "usr/lib"}))
+		}
+		_, err = BuildTreeFromPaths([]map[string]any{{"path": "/a"}, {"path": "a"}}, "path", "/", "")
+		Expect(err).Should(BeNil())
+	})
+	It("of-case1", func() {
+		// children beyond the depth limit are never loaded
+		type dir struct {
+			name  string
+			size  int
+			meta  map[string]any
+			items []dir
+		}
+		root := dir{name: "root", items: []dir{
+			{name: "a", items: []dir{{name: "a1", size: 1}, {name: "a2", size: 2}}},
+			{name: "b", size: 3, meta: map[string]any{"owner": "bob"}},
+		}}
+		loads := []string{}
+		children := func(d dir) []dir {
+			loads = append(loads, d.name)
+			return d.items
+		}
+		fields := map[string]func(dir) any{
+			"name": func(d dir) any { return d.name },
+			"size": func(d dir) any { return d.size },
+			"meta": func(d dir) any { return d.meta },
+		}
+		tr := TreeOf(root, children, fields)
+		Expect(tr.Value().name).Should(Equal("root"))
+		Expect(tr.Fields()).Should(HaveLen(3))
+
+		tb := NewTable(nil).AllowMissingFields("-")
+		tb.AppendColumn(test_NewStdColumn("name"), test_NewStdColumn("owner").FieldPath("meta.owner"))
+		Expect(tb.AppendTreesWithOptions(*DefaultTreePathStyle(), TreeOptions{MaxDepth: 2}, tr)).Should(BeNil())
+		Expect(loads).Should(Equal([]string{"root"}))
+		out, err := tb.Render(Console)
+		Expect(err).Should(BeNil())
+		expects := []string{
+			`+-------+------+-------+`,
+			`| Path  | name | owner |`,
+			`+-------+------+-------+`,
+			`| >-+-- | root | -     |`,
+			`|   +-- | a    | -     |`,
+			`|   \-- | b    | bob   |`,
+			`+-------+------+-------+`,
+			``,
+		}
+		Expect(strings.Split(out, "\n")).Should(Equal(expects))
+
+		// children of collapsed nodes are not loaded
+		loads = loads[:0]
+		tb = NewTable(nil)
+		tb.AppendColumn(test_NewStdColumn("name"))
+		opts := TreeOptions{Collapse: func(n TreeNodeReader) bool { return n.(*Tree[dir]).Value().name == "a" }}
+		Expect(tb.AppendTreesWithOptions(*DefaultTreePathStyle(), opts, TreeOf(root, children, fields))).Should(BeNil())
+		Expect(loads).Should(Equal([]string{"root", "b"}))
+		Expect(tb.RowCount()).Should(Equal(3))
+
+		// children are loaded once to count hidden ones and to aggregate
+		loads = loads[:0]
+		tb = NewTable(nil)
+		tb.AppendColumn(test_NewStdColumn("name"), test_NewStdColumn("size").Aggregate(ReduceSum))
+		sty := DefaultTreePathStyle()
+		sty.Label = "name"
+		opts = TreeOptions{MaxDepth: 2, ElidedMarker: DefaultElidedMarker}
+		Expect(tb.AppendTreesWithOptions(*sty, opts, TreeOf(root, children, fields))).Should(BeNil())
+		Expect(loads).Should(Equal([]string{"root", "a", "a1", "a2", "b"}))
+		out, err = tb.Render(Console)
+		Expect(err).Should(BeNil())
+		expects = []string{
+			`+-------------------+------+------+`,
+			`|       Path        | name | size |`,
+			`+-------------------+------+------+`,
+			`| >-+-- root        | root | 6    |`,
+			`|   +-- a (+2 more) | a    | 3    |`,
+			`|   \-- b           | b    | 3    |`,
+			`+-------------------+------+------+`,
+			``,
+		}
+		Expect(strings.Split(out, "\n")).Should(Equal(expects))
+
+		// a single node without children func
+		tb = NewTable(nil).StrictFields(true)
+		tb.AppendColumn(test_NewStdColumn("name"))
+		err = tb.AppendTrees(*DefaultTreePathStyle(), TreeOf(root, nil, fields))
+		Expect(errors.Is(err, ErrUnknownField)).Should(BeTrue())
+	})
+	It("of-case2", func() {
+		// children are yielded by an iterator
+		seq := func(n int) iter.Seq[int] {
+			return func(yield func(int) bool) {
+				for i := 1; i <= 2 && n < 100; i++ {
+					if !yield(n*10 + i) {
+						return
+					}
+				}
+			}
+		}
+		tr := TreeOfSeq(1, seq, map[string]func(int) any{
+			"id": func(n int) any { return n },
+		})
+		tb := NewTable(nil)
+		sty := IndentTreePathStyle()
+		sty.Label = "id"
+		Expect(tb.AppendTrees(*sty, tr)).Should(BeNil())
+		out, err := tb.Render(Console)
+		Expect(err).Should(BeNil())
+		expects := []string{
+			`+---------+`,
+			`|  Path   |`,
+			`+---------+`,
+			`| 1       |`,
+			`|   11    |`,
+			`|     111 |`,
+			`|     112 |`,
+			`|   12    |`,
+			`|     121 |`,
+			`|     122 |`,
+			`+---------+`,
+			``,
+		}
+		Expect(strings.Split(out, "\n")).Should(Equal(expects))
+	})
+})